@@ -0,0 +1,124 @@
+// Package calendar turns events into iCalendar (ICS) feeds and serves them per team and
+// per tournament, for end users to subscribe to in Google Calendar, Apple Calendar...
+//
+//	server, err := calendar.NewServer(c, calendar.Options{Secret: secret})
+//	mux.Handle("/calendars/", http.StripPrefix("/calendars", server))
+//	link := "webcal://example.com/calendars" + server.FeedPath(calendar.FeedTeam, 12)
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// EventDuration is how long events last in the calendar, the API doesn't say
+const EventDuration = 2 * time.Hour
+
+// Product identifier of the generated calendars
+const prodID = "-//SAPO//vsports-go//EN"
+
+// Longest line allowed by RFC 5545, in octets, longer ones are folded
+const maxLineLength = 75
+
+// Write writes the events as an iCalendar named name
+// Events without a valid start time are left out. Finished events show their score
+func Write(w io.Writer, name string, events []client.Event, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + prodID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escape(name),
+		// Hint for clients to poll the feed every few hours
+		"REFRESH-INTERVAL;VALUE=DURATION:PT6H",
+		"X-PUBLISHED-TTL:PT6H",
+	}
+	stamp := now.UTC().Format(icsTime)
+	for _, event := range events {
+		start, ok := event.Kickoff()
+		if !ok {
+			continue
+		}
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:vsports-event-%d@vsports-go", event.ID),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+start.Format(icsTime),
+			"DTEND:"+start.Add(EventDuration).Format(icsTime),
+			"SUMMARY:"+escape(summary(event)),
+		)
+		if event.Tournament.Name != "" {
+			lines = append(lines, "DESCRIPTION:"+escape(event.Tournament.Name))
+		}
+		if location := location(event.Venue); location != "" {
+			lines = append(lines, "LOCATION:"+escape(location))
+		}
+		if event.OfficialURL != "" {
+			lines = append(lines, "URL:"+event.OfficialURL)
+		}
+		status := "CONFIRMED"
+		if strings.Contains(strings.ToLower(event.Status), "cancel") {
+			status = "CANCELLED"
+		}
+		lines = append(lines, "STATUS:"+status, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(fold(line))
+		b.WriteString("\r\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Format of date-times in UTC
+const icsTime = "20060102T150405Z"
+
+func summary(event client.Event) string {
+	if event.Status == "finished" {
+		return fmt.Sprintf("%s %d-%d %s", event.TeamA.Name, event.Total_A, event.Total_B, event.TeamB.Name)
+	}
+	return fmt.Sprintf("%s vs %s", event.TeamA.Name, event.TeamB.Name)
+}
+
+func location(venue client.Venue) string {
+	parts := []string{}
+	for _, part := range []string{venue.Name, venue.City} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Escapes a text value as RFC 5545 requires
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// Folds a content line into lines of at most 75 octets, without splitting UTF-8 sequences
+func fold(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+	var b strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > maxLineLength {
+			// The continuation starts with a space, which counts towards the length
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length += size
+	}
+	return b.String()
+}
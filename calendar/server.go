@@ -0,0 +1,199 @@
+package calendar
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// Kinds of feeds
+const (
+	FeedTeam       = "teams"
+	FeedTournament = "tournaments"
+)
+
+// Defaults of Options
+const (
+	DefaultTokenRotation = 90 * 24 * time.Hour
+	DefaultDaysBack      = 30
+	DefaultDaysAhead     = 90
+)
+
+// Shortest secret accepted, in bytes
+const minSecretLength = 32
+
+// Options configures a Server
+type Options struct {
+	// Key the feed tokens are derived from, at least 32 bytes. Changing it revokes every link
+	Secret []byte
+	// How often the token of each feed changes, DefaultTokenRotation when zero
+	// The token of the previous period is still accepted, so a link keeps working for
+	// one to two periods: hand out fresh links, e.g. every time the page is shown
+	TokenRotation time.Duration
+	// Days of past and future events in the feeds, DefaultDaysBack and DefaultDaysAhead when zero
+	DaysBack  int
+	DaysAhead int
+	// client.SystemClock when nil
+	Clock client.Clock
+	// Logger of the errors of the server, none when nil
+	Logger *slog.Logger
+}
+
+// Server serves a calendar feed per team and per tournament:
+//
+//	GET /teams/{teamID}.ics?token=...
+//	GET /tournaments/{tournamentID}.ics?token=...
+//
+// Every feed has a token of its own, derived from the secret, so a link can't be
+// turned into another feed's. Requests with a missing or expired token get a 404
+type Server struct {
+	client *client.VSportsClient_s
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// NewServer creates a feed server getting the events from the client, and its cache
+func NewServer(c *client.VSportsClient_s, opts Options) (*Server, error) {
+	if len(opts.Secret) < minSecretLength {
+		return nil, fmt.Errorf("calendar: the secret must be at least %d bytes", minSecretLength)
+	}
+	if opts.TokenRotation < 0 || opts.DaysBack < 0 || opts.DaysAhead < 0 {
+		return nil, errors.New("calendar: the token rotation and days must not be negative")
+	}
+	if opts.TokenRotation == 0 {
+		opts.TokenRotation = DefaultTokenRotation
+	}
+	if opts.TokenRotation < time.Second {
+		return nil, errors.New("calendar: the token rotation must be at least a second")
+	}
+	if opts.DaysBack == 0 {
+		opts.DaysBack = DefaultDaysBack
+	}
+	if opts.DaysAhead == 0 {
+		opts.DaysAhead = DefaultDaysAhead
+	}
+	if opts.Clock == nil {
+		opts.Clock = client.SystemClock{}
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	s := &Server{client: c, opts: opts, mux: http.NewServeMux()}
+	s.mux.Handle("GET /"+FeedTeam+"/{file}", s.feed(FeedTeam))
+	s.mux.Handle("GET /"+FeedTournament+"/{file}", s.feed(FeedTournament))
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Token returns the current token of the feed of a team or tournament
+func (s *Server) Token(kind string, id int) string {
+	return s.token(kind, id, s.period(s.opts.Clock.Now()))
+}
+
+// FeedPath returns the path of the feed with its current token, relative to where the
+// server is mounted, e.g. "/teams/12.ics?token=..."
+func (s *Server) FeedPath(kind string, id int) string {
+	return fmt.Sprintf("/%s/%d.ics?token=%s", kind, id, url.QueryEscape(s.Token(kind, id)))
+}
+
+// WebcalURL turns the http(s) URL of a feed into a webcal one, which opens the
+// subscription dialog of the calendar apps
+func WebcalURL(feedURL string) string {
+	if rest, ok := strings.CutPrefix(feedURL, "https://"); ok {
+		return "webcal://" + rest
+	}
+	if rest, ok := strings.CutPrefix(feedURL, "http://"); ok {
+		return "webcal://" + rest
+	}
+	return feedURL
+}
+
+func (s *Server) period(now time.Time) int64 {
+	return now.Unix() / int64(s.opts.TokenRotation/time.Second)
+}
+
+func (s *Server) token(kind string, id int, period int64) string {
+	mac := hmac.New(sha256.New, s.opts.Secret)
+	fmt.Fprintf(mac, "%s/%d/%d", kind, id, period)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:18])
+}
+
+// Tells if the token is the one of the current or the previous period
+func (s *Server) validToken(kind string, id int, token string) bool {
+	period := s.period(s.opts.Clock.Now())
+	for _, p := range []int64{period, period - 1} {
+		if hmac.Equal([]byte(token), []byte(s.token(kind, id, p))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) feed(kind string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+		id, err := strconv.Atoi(name)
+		if !ok || err != nil || id <= 0 || !s.validToken(kind, id, r.URL.Query().Get("token")) {
+			http.NotFound(w, r)
+			return
+		}
+
+		now := s.opts.Clock.Now().UTC()
+		today := client.DateOf(now)
+		events, err := s.client.GetEventsByDate(r.Context(), today.AddDays(-s.opts.DaysBack), today.AddDays(s.opts.DaysAhead))
+		if err != nil {
+			s.opts.Logger.Warn(fmt.Sprintf("Error getting the events of the %s %d calendar: %v", kind, id, err))
+			http.Error(w, "calendar unavailable", http.StatusBadGateway)
+			return
+		}
+
+		var kept []client.Event
+		title := ""
+		for _, event := range events {
+			name := ""
+			switch {
+			case kind == FeedTournament && event.Tournament.ID == id:
+				name = event.Tournament.Name
+			case kind == FeedTeam && event.TeamA.ID == id:
+				name = event.TeamA.Name
+			case kind == FeedTeam && event.TeamB.ID == id:
+				name = event.TeamB.Name
+			default:
+				continue
+			}
+			if name != "" {
+				title = name
+			}
+			kept = append(kept, event)
+		}
+		if title == "" {
+			title = fmt.Sprintf("%s %d", strings.TrimSuffix(kind, "s"), id)
+		}
+
+		var body bytes.Buffer
+		if err := Write(&body, title, kept, now); err != nil {
+			s.opts.Logger.Error(fmt.Sprintf("Error writing the %s %d calendar: %v", kind, id, err))
+			http.Error(w, "calendar unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+		w.Write(body.Bytes())
+	})
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// How many requests an aggregate call makes at once
+const aggregateConcurrency = 4
+
+// How far ahead aggregate calls look for upcoming events
+const upcomingEventsDays = 14
+
+// Date format expected by the events endpoints
+const eventsDateFormat = "2006-01-02"
+
+// Runs the tasks with at most limit of them at once, returning all their errors joined
+func runConcurrently(limit int, tasks ...func() error) error {
+	if limit <= 0 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	errs := make([]error, len(tasks))
+
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = task()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Fetches the events between two days, keeping those matching the filter
+func (c *VSportsClient_s) eventsBetween(ctx context.Context, from, to time.Time, keep func(Event) bool, opts ...RequestOption) ([]Event, error) {
+	events, err := c.GetEventsByDate(ctx, DateOf(from), DateOf(to), opts...)
+	if err != nil {
+		return nil, err
+	}
+	var kept []Event
+	for _, event := range events {
+		if keep(event) {
+			kept = append(kept, event)
+		}
+	}
+	return kept, nil
+}
+
+// Returns a task setting *part to what fetch returns, only when it succeeds, as some
+// methods return what they decoded along with their error. A failed part stays empty
+func fetchPart[T any](part *T, fetch func() (T, error)) func() error {
+	return func() error {
+		value, err := fetch()
+		if err == nil {
+			*part = value
+		}
+		return err
+	}
+}
+
+// TournamentOverview gathers what a competition landing page shows
+type TournamentOverview struct {
+	Tournament *Tournament `json:"tournament"`
+	Teams      []Team      `json:"teams"`
+	Standings  *Standings  `json:"standings"`
+	// Events of the tournament from today on, for the next two weeks
+	UpcomingEvents []Event `json:"upcomingEvents"`
+}
+
+// GetTournamentOverview fetches the tournament, its teams, its current standings and its
+// upcoming events concurrently. Each part is cached like the call fetching it, and only
+// the cache options, like WithNoCache, apply to them. When some parts fail, the overview holds the others and the error joins the failures
+func (c *VSportsClient_s) GetTournamentOverview(ctx context.Context, tournamentID int, opts ...RequestOption) (*TournamentOverview, error) {
+	overview := &TournamentOverview{}
+	today := c.clock.Now().UTC()
+	cached := cacheOptions(opts)
+
+	err := runConcurrently(aggregateConcurrency,
+		fetchPart(&overview.Tournament, func() (*Tournament, error) {
+			return c.GetTournamentById(ctx, tournamentID, cached)
+		}),
+		fetchPart(&overview.Teams, func() ([]Team, error) {
+			return c.GetTeamsByTournamentId(ctx, tournamentID, cached)
+		}),
+		fetchPart(&overview.Standings, func() (*Standings, error) {
+			return c.GetStandingsByTournament(ctx, tournamentID, cached)
+		}),
+		fetchPart(&overview.UpcomingEvents, func() ([]Event, error) {
+			return c.eventsBetween(ctx, today, today.AddDate(0, 0, upcomingEventsDays), func(event Event) bool {
+				return event.Tournament.ID == tournamentID
+			}, cached)
+		}),
+	)
+	if err != nil {
+		return overview, fmt.Errorf("error getting overview of tournament %d: %w", tournamentID, err)
+	}
+	return overview, nil
+}
+
+// How far back GetTeamOverview looks for results
+const recentResultsDays = 30
+
+// TeamOverview gathers what a team page shows
+type TeamOverview struct {
+	Team   *Team   `json:"team"`
+	Squad  *Squad  `json:"squad"`
+	Venues []Venue `json:"venues"`
+	// Events of the team over the last 30 days, most recent first
+	RecentResults []Event `json:"recentResults"`
+	// Events of the team from today on, for the next two weeks, soonest first
+	UpcomingFixtures []Event `json:"upcomingFixtures"`
+}
+
+// GetTeamOverview fetches the team, its squad, its venues, its recent results and its
+// upcoming fixtures concurrently. Each part is cached like the call fetching it, and only
+// the cache options, like WithNoCache, apply to them
+// Finished events are results and live ones fixtures. The others are told apart by their
+// date, so today's are upcoming fixtures, and left out when they have none
+// When some parts fail, the overview holds the others and the error joins the failures
+func (c *VSportsClient_s) GetTeamOverview(ctx context.Context, teamID int, opts ...RequestOption) (*TeamOverview, error) {
+	overview := &TeamOverview{}
+	today := c.clock.Now().UTC()
+	cached := cacheOptions(opts)
+
+	err := runConcurrently(aggregateConcurrency,
+		fetchPart(&overview.Team, func() (*Team, error) {
+			return c.GetTeamById(ctx, teamID, cached)
+		}),
+		fetchPart(&overview.Squad, func() (*Squad, error) {
+			return c.GetSquad(ctx, teamID, cached)
+		}),
+		fetchPart(&overview.Venues, func() ([]Venue, error) {
+			return c.GetVenuesByTeam(ctx, teamID, cached)
+		}),
+		func() error {
+			// One call covers both the results and the fixtures
+			events, err := c.eventsBetween(ctx, today.AddDate(0, 0, -recentResultsDays), today.AddDate(0, 0, upcomingEventsDays), func(event Event) bool {
+				return event.TeamA.ID == teamID || event.TeamB.ID == teamID
+			}, cached)
+			if err != nil {
+				return err
+			}
+			overview.RecentResults, overview.UpcomingFixtures = splitResults(events, DateOf(today))
+			return nil
+		},
+	)
+	if err != nil {
+		return overview, fmt.Errorf("error getting overview of team %d: %w", teamID, err)
+	}
+	return overview, nil
+}
+
+// Splits events into results, most recent first, and fixtures, soonest first
+// Finished and live events go by their status, the others by whether they're before the
+// given day, skipped when they have no date
+func splitResults(events []Event, day Date) (results, fixtures []Event) {
+	sortByDate(events)
+	for _, event := range events {
+		switch {
+		case event.Status == EventStatusFinished:
+			results = append(results, event)
+		case event.Status == EventStatusLive:
+			fixtures = append(fixtures, event)
+		case event.DateTime.IsZero():
+			continue
+		case event.DateTime.Before(day.Time()):
+			results = append(results, event)
+		default:
+			fixtures = append(fixtures, event)
+		}
+	}
+	slices.Reverse(results)
+	return results, fixtures
+}
+
+// Sorts events by date and time, soonest first
+func sortByDate(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].DateTime.Before(events[j].DateTime)
+	})
+}
+
+// Fetches the detailed events of a tournament between two dates
+func (c *VSportsClient_s) tournamentEventsDetailed(ctx context.Context, tournamentID int, startDate, endDate Date, opts ...RequestOption) ([]Event, error) {
+	events, err := c.GetEventsDetailedByDate(ctx, startDate, endDate, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting events of tournament %d: %w", tournamentID, err)
+	}
+	var kept []Event
+	for _, event := range events {
+		if event.Tournament.ID == tournamentID {
+			kept = append(kept, event)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,39 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestOverviewLeavesFailedPartsNil(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tournaments/101":
+			// Decodes partly, the name isn't a string
+			w.Write([]byte(`{"id": 101, "name": 7}`))
+		case strings.HasPrefix(r.URL.Path, "/teams/by/tournament/"):
+			w.Write([]byte(`[{"id": 201, "name": "Clube A"}]`))
+		case strings.HasPrefix(r.URL.Path, "/standings/"):
+			w.Write([]byte(`{"stage": []}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	})
+	c := newTestClient(t, client.ClientConfig{}, api.URL)
+
+	overview, err := c.GetTournamentOverview(context.Background(), 101)
+	if !errors.Is(err, client.ErrMalformedResponse) {
+		t.Fatalf("got %v, want ErrMalformedResponse", err)
+	}
+	if overview.Tournament != nil {
+		t.Errorf("got tournament %+v, want nil", overview.Tournament)
+	}
+	if len(overview.Teams) != 1 || overview.Standings == nil {
+		t.Errorf("got teams %v and standings %v, want the parts that succeeded", overview.Teams, overview.Standings)
+	}
+}
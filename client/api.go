@@ -0,0 +1,18 @@
+package client
+
+import "context"
+
+// VSportsAPI is the set of read operations of VSportsClient_s that code using the client
+// usually needs. Depend on it rather than on *VSportsClient_s to substitute a fake in
+// unit tests, e.g. the one of the vsportstest package
+type VSportsAPI interface {
+	Provider
+
+	GetTournamentsByType(ctx context.Context, types []CompetitionType, opts ...RequestOption) ([]Tournament, error)
+	GetEventsDetailedByDate(ctx context.Context, startDate, endDate Date, opts ...RequestOption) ([]Event, error)
+	GetEventDetailed(ctx context.Context, eventID int, opts ...RequestOption) (*Event, error)
+	GetSquadDetailed(ctx context.Context, teamID int, opts ...RequestOption) (*Squad, error)
+	GetStandingsByTournamentLive(ctx context.Context, tournamentID int, opts ...RequestOption) (*Standings, error)
+}
+
+var _ VSportsAPI = (*VSportsClient_s)(nil)
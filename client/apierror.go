@@ -0,0 +1,126 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Errors matched by an APIError, depending on its status, e.g. errors.Is(err, ErrNotFound)
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("resource not found")
+	ErrRateLimited  = errors.New("rate limited by the API")
+	ErrServerError  = errors.New("API server error")
+)
+
+// Only the start of error bodies is kept
+const maxErrorBodyBytes = 4 << 10
+
+// APIError is returned when the API answers with an error status
+// Use errors.As to get at it, or errors.Is with ErrNotFound and the like to branch on it
+type APIError struct {
+	StatusCode int
+	// Endpoint requested, e.g. "teams/12", and the base URL that answered
+	Endpoint string
+	BaseURL  string
+	// Start of the response body, and the message found in it if any
+	Body    []byte
+	Message string
+	// How long the API asked to wait before retrying, zero when it didn't say
+	RetryAfter time.Duration
+}
+
+func newAPIError(resp *http.Response, baseURL, endpoint string, body []byte, now time.Time) *APIError {
+	if len(body) > maxErrorBodyBytes {
+		body = body[:maxErrorBodyBytes]
+	}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   endpoint,
+		BaseURL:    baseURL,
+		Body:       body,
+		Message:    errorMessage(body),
+	}
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), now)
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s answered %d %s for %s", e.BaseURL, e.StatusCode, http.StatusText(e.StatusCode), e.Endpoint)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Tells if the API refused the request for good, e.g. a 404, so neither another base
+// URL nor the stale copy of the response should be tried. Authentication failures
+// and rate limiting don't count, as they don't depend on the request
+func (e *APIError) definitive() bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+// Tells if err is an APIError refusing the request for good
+func isDefinitive(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.definitive()
+}
+
+// Tells if err is an APIError refusing the credentials of the request, a 401 or a 403
+func isAuthFailure(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// Returns the message of a JSON error body, e.g. {"message": "..."} or {"error": "..."}
+func errorMessage(body []byte) string {
+	var fields struct {
+		Message string          `json:"message"`
+		Error   json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	if fields.Message != "" {
+		return strings.TrimSpace(fields.Message)
+	}
+	var text string
+	if json.Unmarshal(fields.Error, &text) == nil {
+		return strings.TrimSpace(text)
+	}
+	var nested struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(fields.Error, &nested) == nil {
+		return strings.TrimSpace(nested.Message)
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Cache decisions reported in audit records
+const (
+	// Served from the cache, no upstream call was made
+	AuditCacheHit = "hit"
+	// Not in the cache, fetched upstream
+	AuditCacheMiss = "miss"
+	// The caller skipped the cache, fetched upstream
+	AuditCacheBypass = "bypass"
+	// The call budget was exhausted and the cached copy was served instead
+	AuditCacheBudgetFallback = "budget-fallback"
+	// The API failed and the last good response was served instead
+	AuditCacheStale = "stale"
+	// Served from the cache past its freshness, while it's refreshed in the background
+	AuditCacheRevalidate = "revalidate"
+	// Fetched upstream to replace the cached copy, without looking at it
+	AuditCacheRefresh = "refresh"
+	// Got the response of an identical request in flight, made by another caller
+	AuditCacheShared = "shared"
+	// Revalidated upstream with a conditional request, the API answered 304 Not Modified
+	// and the cached copy was served and renewed
+	AuditCacheNotModified = "not-modified"
+)
+
+// AuditRecord describes one request handled by the client
+// Status and Bytes are those of the last upstream call, zero when none was made
+type AuditRecord struct {
+	Time     time.Time         `json:"time"`
+	Endpoint string            `json:"endpoint"`
+	Params   map[string]string `json:"params,omitempty"`
+	Cache    string            `json:"cache"`
+	Upstream bool              `json:"upstream"`
+	Status   int               `json:"status,omitempty"`
+	Bytes    int               `json:"bytes"`
+	Duration time.Duration     `json:"durationNs"`
+	Error    string            `json:"error,omitempty"`
+	// Every upstream call made for the request, retries and failovers included, in order
+	Attempts []AuditAttempt `json:"attempts,omitempty"`
+}
+
+// AuditAttempt describes one upstream call made for a request
+// Status is zero when the API couldn't be reached
+type AuditAttempt struct {
+	Time     time.Time     `json:"time"`
+	BaseURL  string        `json:"baseUrl"`
+	Status   int           `json:"status,omitempty"`
+	Bytes    int           `json:"bytes"`
+	Duration time.Duration `json:"durationNs"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// AuditSink receives a record for every request handled by the client
+// Record is called synchronously at the end of each request, so it should be quick
+type AuditSink interface {
+	Record(record AuditRecord)
+}
+
+// AuditFunc adapts a plain function to the AuditSink interface
+type AuditFunc func(record AuditRecord)
+
+func (f AuditFunc) Record(record AuditRecord) { f(record) }
+
+// WriterAuditSink writes audit records to a writer as JSON lines
+type WriterAuditSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterAuditSink creates an audit sink writing one JSON object per line to w
+func NewWriterAuditSink(w io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *WriterAuditSink) Record(record AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// There's nowhere to report a failing audit writer, and the request itself went fine
+	_ = s.encoder.Encode(record)
+}
+
+// Sends the record of a request that ended with err to the audit sink, if any
+func (c *VSportsClient_s) audit(record AuditRecord, err error) {
+	if c.auditSink == nil {
+		return
+	}
+	record.Duration = c.clock.Now().Sub(record.Time)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	c.safeCall("audit sink", func() error {
+		c.auditSink.Record(record)
+		return nil
+	})
+}
+
+// SetAuditSink sets the sink receiving a record of every request
+// Passing nil disables auditing. It must be called before the client is used concurrently
+func (c *VSportsClient_s) SetAuditSink(sink AuditSink) {
+	c.auditSink = sink
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// KeyProvider supplies the API key used to authenticate requests
+// It is consulted once per request, so implementations can rotate keys at any time
+type KeyProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// KeyProviderFunc adapts a plain function to the KeyProvider interface
+type KeyProviderFunc func(ctx context.Context) (string, error)
+
+func (f KeyProviderFunc) APIKey(ctx context.Context) (string, error) { return f(ctx) }
+
+// StaticKey is a KeyProvider that always returns the same key
+type StaticKey string
+
+func (k StaticKey) APIKey(ctx context.Context) (string, error) {
+	if k == "" {
+		return "", errors.New("no API key configured")
+	}
+	return string(k), nil
+}
+
+// Holds the key provider of a client
+// Swapping the provider is safe while requests are in flight: each request reads
+// the provider once and keeps using the key it got
+type keyHolder struct {
+	mu       sync.RWMutex
+	provider KeyProvider
+}
+
+func newKeyHolder(provider KeyProvider) *keyHolder {
+	return &keyHolder{provider: provider}
+}
+
+func (h *keyHolder) get() KeyProvider {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.provider
+}
+
+func (h *keyHolder) set(provider KeyProvider) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.provider = provider
+}
+
+// Replaces a static key with another one, leaving any other provider as it is
+// Returns false when the provider isn't a StaticKey
+func (h *keyHolder) replaceStatic(key StaticKey) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, static := h.provider.(StaticKey); !static {
+		return false
+	}
+	h.provider = key
+	return true
+}
+
+func (h *keyHolder) apiKey(ctx context.Context) (string, error) {
+	return h.get().APIKey(ctx)
+}
+
+// SetAPIKey replaces the API key used by the client
+// Requests already in flight finish with the key they started with
+func (c *VSportsClient_s) SetAPIKey(key string) {
+	c.keys.set(StaticKey(key))
+}
+
+// SetKeyProvider makes the client ask the given provider for the API key on every request
+func (c *VSportsClient_s) SetKeyProvider(provider KeyProvider) {
+	if provider == nil {
+		return
+	}
+	c.keys.set(provider)
+}
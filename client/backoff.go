@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff tells how long to wait before an attempt of something that failed
+// The client uses one between retries, and another one to keep a failing base URL out
+// of rotation. Implement it to tune either without touching the client
+type Backoff interface {
+	// Delay returns the wait before the given attempt, counting from 1 for the first
+	// retry. previous is the delay returned for the attempt before, zero for the first
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// BackoffFunc adapts a plain function to the Backoff interface
+type BackoffFunc func(attempt int, previous time.Duration) time.Duration
+
+func (f BackoffFunc) Delay(attempt int, previous time.Duration) time.Duration {
+	return f(attempt, previous)
+}
+
+// ConstantBackoff waits the same time before every attempt
+type ConstantBackoff time.Duration
+
+func (b ConstantBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier on every attempt, from Initial up to Max
+type ExponentialBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	// 2 when not set
+	Multiplier float64
+	// Fraction of the delay randomly added or removed, from 0 to 1, so clients failing
+	// together don't retry together
+	Jitter float64
+}
+
+func (b ExponentialBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	if b.Max > 0 {
+		delay = min(delay, float64(b.Max))
+	}
+	if b.Jitter > 0 {
+		delay += delay * min(b.Jitter, 1) * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitterBackoff picks each delay at random between Base and three times the
+// previous one, up to Max. It spreads the retries of many clients better than an
+// exponential backoff with jitter
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b DecorrelatedJitterBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	upper := max(3*previous, b.Base)
+	delay := b.Base + rand.N(upper-b.Base+1)
+	if b.Max > 0 {
+		delay = min(delay, b.Max)
+	}
+	return delay
+}
+
+// DefaultRetryBackoff is used between retries when ClientConfig.RetryBackoff isn't set
+var DefaultRetryBackoff Backoff = ExponentialBackoff{Initial: 200 * time.Millisecond, Max: 5 * time.Second, Jitter: 0.2}
+
+// DefaultFailoverBackoff is used when ClientConfig.FailoverBackoff isn't set
+var DefaultFailoverBackoff Backoff = ConstantBackoff(30 * time.Second)
+
+// Shortest time an upstream attempt can take. Retries with less time left are skipped
+const minAttemptDuration = 50 * time.Millisecond
+
+// Tells if an attempt started after waiting delay can still finish before the deadline of
+// the context. Deadlines are set on the wall clock, so it's used here instead of the client clock
+func deadlineAllows(ctx context.Context, delay time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay+minAttemptDuration
+}
+
+// Returns the error to report for an attempt cut short by the context: the error of the
+// previous attempt, when there was one, says more than "context deadline exceeded"
+func attemptError(ctx context.Context, err, lastErr error) error {
+	if lastErr != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return lastErr
+	}
+	return err
+}
+
+// Waits for the delay, returning early with the context error if it's cancelled
+func sleepContext(ctx context.Context, clock Clock, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(delay):
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestRetrySkippedPastDeadline(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  3,
+		RetryBackoff: client.ConstantBackoff(time.Second),
+	}, api.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetRaw(ctx, "teams/1", nil)
+
+	// Waiting a second can't end before the deadline, so there's no point in it
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want the 503 of the API", err)
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("returned after %s, want right away", elapsed)
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestCancelledRetryReturnsLastError(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c := newTestClient(t, client.ClientConfig{}, api.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	// A backoff of its own keeps the request out of the calls shared in flight, which
+	// leave a caller that stops waiting with its context error
+	_, err := c.GetRaw(ctx, "teams/1", nil, client.WithRetries(3, client.ConstantBackoff(time.Second)))
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want the 503 of the API rather than context.Canceled", err)
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
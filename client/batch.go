@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// The batch methods fetch many resources by ID concurrently, DefaultBatchConcurrency at
+// once unless WithConcurrency says otherwise. A failing ID doesn't sink the batch: the
+// resources fetched are returned, in the order of the IDs or keyed by ID, along with an
+// error joining an *ItemError per failed ID. Use FailedIDs to tell which ones to retry
+// The options apply to each request, but WithRawResponse
+
+// DefaultBatchConcurrency is how many requests the batch methods make at once by default
+const DefaultBatchConcurrency = aggregateConcurrency
+
+// WithConcurrency sets how many requests a batch method makes at once, e.g. more for a
+// dashboard of many tournaments. Other methods ignore it
+func WithConcurrency(n int) RequestOption {
+	return func(o *requestOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// ItemError is the error of one ID of a batch call
+type ItemError struct {
+	ID  int
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("id %d: %v", e.ID, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// FailedIDs returns the IDs that failed in the error of a batch call, in the order of the call
+func FailedIDs(err error) []int {
+	var ids []int
+	var walk func(error)
+	walk = func(err error) {
+		var item *ItemError
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				walk(err)
+			}
+		} else if errors.As(err, &item) {
+			ids = append(ids, item.ID)
+		}
+	}
+	if err != nil {
+		walk(err)
+	}
+	return ids
+}
+
+// Fetches the resources of the IDs concurrently, skipping duplicates, and returns those
+// fetched in the order of the IDs with the errors of the others joined
+func batch[T any](ids []int, opts []RequestOption, fetch func(id int) (*T, error)) ([]T, error) {
+	_, results, err := fetchBatch(ids, opts, fetch)
+	items := make([]T, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			items = append(items, *result)
+		}
+	}
+	return items, err
+}
+
+// Same as batch, with the resources fetched keyed by ID
+func batchByID[T any](ids []int, opts []RequestOption, fetch func(id int) (*T, error)) (map[int]T, error) {
+	unique, results, err := fetchBatch(ids, opts, fetch)
+	items := make(map[int]T, len(results))
+	for i, result := range results {
+		if result != nil {
+			items[unique[i]] = *result
+		}
+	}
+	return items, err
+}
+
+// Returns the unique IDs, in order, with the resource fetched for each, nil when it failed
+func fetchBatch[T any](ids []int, opts []RequestOption, fetch func(id int) (*T, error)) ([]int, []*T, error) {
+	seen := make(map[int]bool, len(ids))
+	var unique []int
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	results := make([]*T, len(unique))
+	errs := make([]error, len(unique))
+	tasks := make([]func() error, len(unique))
+	for i, id := range unique {
+		tasks[i] = func() error {
+			result, err := fetch(id)
+			if err != nil {
+				// Some methods return what they could decode along with the error
+				errs[i] = &ItemError{ID: id, Err: err}
+				return nil
+			}
+			results[i] = result
+			return nil
+		}
+	}
+	concurrency := DefaultBatchConcurrency
+	if o := applyOptions(nil, opts); o.concurrency > 0 {
+		concurrency = o.concurrency
+	}
+	runConcurrently(concurrency, tasks...)
+	return unique, results, errors.Join(errs...)
+}
+
+// GetTournamentsByIds fetches several tournaments, see the batch methods above
+func (c *VSportsClient_s) GetTournamentsByIds(ctx context.Context, tournamentIDs []int, opts ...RequestOption) ([]Tournament, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batch(tournamentIDs, opts, func(id int) (*Tournament, error) {
+		return c.GetTournamentById(ctx, id, opts...)
+	})
+}
+
+// GetTeamsByIds fetches several teams, see the batch methods above
+func (c *VSportsClient_s) GetTeamsByIds(ctx context.Context, teamIDs []int, opts ...RequestOption) ([]Team, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batch(teamIDs, opts, func(id int) (*Team, error) {
+		return c.GetTeamById(ctx, id, opts...)
+	})
+}
+
+// GetEventsByIds fetches several events, see the batch methods above
+func (c *VSportsClient_s) GetEventsByIds(ctx context.Context, eventIDs []int, opts ...RequestOption) ([]Event, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batch(eventIDs, opts, func(id int) (*Event, error) {
+		return c.GetEventById(ctx, id, opts...)
+	})
+}
+
+// GetPersonsByIds fetches several persons, see the batch methods above
+func (c *VSportsClient_s) GetPersonsByIds(ctx context.Context, personIDs []int, opts ...RequestOption) ([]Person, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batch(personIDs, opts, func(id int) (*Person, error) {
+		return c.GetPersonById(ctx, id, opts...)
+	})
+}
+
+// GetSquadsByTeamIds fetches the squads of several teams, see the batch methods above
+func (c *VSportsClient_s) GetSquadsByTeamIds(ctx context.Context, teamIDs []int, opts ...RequestOption) ([]Squad, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batch(teamIDs, opts, func(id int) (*Squad, error) {
+		return c.GetSquad(ctx, id, opts...)
+	})
+}
+
+// GetStandingsByTournamentIds fetches the standings of several tournaments, keyed by
+// tournament ID, see the batch methods above
+func (c *VSportsClient_s) GetStandingsByTournamentIds(ctx context.Context, tournamentIDs []int, opts ...RequestOption) (map[int]Standings, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batchByID(tournamentIDs, opts, func(id int) (*Standings, error) {
+		return c.GetStandingsByTournament(ctx, id, opts...)
+	})
+}
+
+// GetStandingsByTournamentIdsLive is GetStandingsByTournamentIds with the live standings,
+// e.g. for a dashboard of the competitions being played
+func (c *VSportsClient_s) GetStandingsByTournamentIdsLive(ctx context.Context, tournamentIDs []int, opts ...RequestOption) (map[int]Standings, error) {
+	opts = append(slices.Clip(opts), withoutRawResponse())
+	return batchByID(tournamentIDs, opts, func(id int) (*Standings, error) {
+		return c.GetStandingsByTournamentLive(ctx, id, opts...)
+	})
+}
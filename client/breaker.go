@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of the circuit breakers
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenSeconds      = 30
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker of the
+// endpoint group is open. Cached responses are still served
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Endpoint groups with a circuit breaker of their own
+// Live data (events and live standings) fails independently from the static data
+// (tournaments, teams, squads, venues, persons...), which is cached for long anyway
+const (
+	EndpointGroupLive   = "live"
+	EndpointGroupStatic = "static"
+)
+
+// States of a circuit breaker
+const (
+	BreakerClosed = "closed"
+	BreakerOpen   = "open"
+	// Open time is over, a single request is let through to test the API
+	BreakerHalfOpen = "half-open"
+)
+
+// CircuitBreaker configures the circuit breakers of the endpoint groups
+// After FailureThreshold failed requests in a row, the breaker of the group opens and
+// requests to it fail fast with ErrCircuitOpen for OpenSeconds. A trial request then
+// closes it again if it succeeds
+type CircuitBreaker struct {
+	Disabled         bool `json:"disabled"`
+	FailureThreshold int  `json:"failureThreshold"`
+	OpenSeconds      int  `json:"openSeconds"`
+}
+
+// BreakerState is the state of the circuit breaker of an endpoint group
+type BreakerState struct {
+	Group               string    `json:"group"`
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	OpenedAt            time.Time `json:"openedAt,omitempty"`
+	// When an open breaker lets a trial request through
+	RetryAt   time.Time `json:"retryAt,omitempty"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// EndpointGroup returns the group of an endpoint, e.g. EndpointGroupLive for "events/12/detailed"
+func EndpointGroup(endpoint string) string {
+	endpoint = strings.Trim(endpoint, "/")
+	if strings.HasPrefix(endpoint, "events") || strings.HasSuffix(endpoint, "/live") {
+		return EndpointGroupLive
+	}
+	return EndpointGroupStatic
+}
+
+// The circuit breakers, one per endpoint group
+type breakers struct {
+	config CircuitBreaker
+
+	mu     sync.Mutex
+	states map[string]*BreakerState
+	// Groups whose trial request is in flight
+	probing map[string]bool
+}
+
+func newBreakers(config CircuitBreaker) *breakers {
+	return &breakers{config: config, states: map[string]*BreakerState{}, probing: map[string]bool{}}
+}
+
+func (b *breakers) stateLocked(group string) *BreakerState {
+	state, ok := b.states[group]
+	if !ok {
+		state = &BreakerState{Group: group, State: BreakerClosed}
+		b.states[group] = state
+	}
+	return state
+}
+
+// Tells if a request to the group may go ahead
+// Once the open time is over, only one request at a time is let through until one succeeds
+func (b *breakers) allow(group string, now time.Time) error {
+	if b.config.Disabled {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.stateLocked(group)
+	if state.State == BreakerOpen && !now.Before(state.RetryAt) {
+		state.State = BreakerHalfOpen
+	}
+	switch {
+	case state.State == BreakerOpen:
+		return fmt.Errorf("%w for %s endpoints until %s: %s", ErrCircuitOpen, group, state.RetryAt.Format(time.TimeOnly), state.LastError)
+	case state.State == BreakerHalfOpen && b.probing[group]:
+		return fmt.Errorf("%w for %s endpoints, waiting for a trial request: %s", ErrCircuitOpen, group, state.LastError)
+	case state.State == BreakerHalfOpen:
+		b.probing[group] = true
+	}
+	return nil
+}
+
+// Records the outcome of a request allowed by allow
+// It returns the previous and new states of the breaker, and whether they differ
+func (b *breakers) record(group string, err error, now time.Time) (from, to string, changed bool) {
+	if b.config.Disabled {
+		return "", "", false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.stateLocked(group)
+	previous := state.State
+	delete(b.probing, group)
+	if err == nil {
+		*state = BreakerState{Group: group, State: BreakerClosed}
+		return previous, BreakerClosed, previous != BreakerClosed
+	}
+
+	state.ConsecutiveFailures++
+	state.LastError = err.Error()
+	if previous == BreakerHalfOpen || state.ConsecutiveFailures >= b.config.FailureThreshold {
+		state.State = BreakerOpen
+		state.OpenedAt = now
+		state.RetryAt = now.Add(time.Duration(b.config.OpenSeconds) * time.Second)
+		return previous, BreakerOpen, previous != BreakerOpen
+	}
+	return previous, previous, false
+}
+
+// Gives back a request allowed by allow that wasn't made after all
+func (b *breakers) release(group string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.probing, group)
+}
+
+func (b *breakers) snapshot(now time.Time) []BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]BreakerState, 0, len(b.states))
+	for _, state := range b.states {
+		s := *state
+		if s.State == BreakerOpen && !now.Before(s.RetryAt) {
+			s.State = BreakerHalfOpen
+		}
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Group < states[j].Group })
+	return states
+}
+
+// Records the outcome of an upstream request in the breaker of its group
+// Requests cut short by the caller, the call budget or the rate limit say nothing about
+// the API, and requests it refused for good, e.g. with a 404, show it's up
+func (c *VSportsClient_s) recordBreaker(ctx context.Context, group string, err error) {
+	if err != nil && (ctx.Err() != nil || errors.Is(err, ErrBudgetExceeded) || errors.Is(err, ErrRateBudgetExceeded)) {
+		c.breakers.release(group)
+		return
+	}
+	// The API answered. Refused credentials are the doing of a key, not of the endpoints,
+	// and the breakers are shared by every client of a ClientManager
+	if isDefinitive(err) || isAuthFailure(err) {
+		err = nil
+	}
+	from, to, changed := c.breakers.record(group, err, c.clock.Now())
+	if !changed {
+		return
+	}
+	c.observe(func(o Observer) { o.OnBreakerStateChange(group, from, to) })
+	if to == BreakerOpen {
+		c.logger.Error(fmt.Sprintf("Opening the circuit breaker of %s endpoints for %ds: %v", group, c.breakers.config.OpenSeconds, err))
+	} else {
+		c.logger.Info(fmt.Sprintf("Closing the circuit breaker of %s endpoints", group))
+	}
+}
+
+// BreakerStates returns the state of the circuit breaker of each endpoint group used so far
+func (c *VSportsClient_s) BreakerStates() []BreakerState {
+	return c.breakers.snapshot(c.clock.Now())
+}
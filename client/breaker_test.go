@@ -0,0 +1,85 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func breakerState(c *client.VSportsClient_s, group string) string {
+	for _, state := range c.BreakerStates() {
+		if state.Group == group {
+			return state.State
+		}
+	}
+	return client.BreakerClosed
+}
+
+func TestBreakerOpensAndCloses(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{"id": 1}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c := newTestClient(t, client.ClientConfig{
+		Clock:          clock,
+		MaxAttempts:    1,
+		CircuitBreaker: client.CircuitBreaker{FailureThreshold: 2, OpenSeconds: 60},
+	}, api.URL)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.GetRaw(ctx, "teams/1", nil, client.WithNoCache()); !errors.Is(err, client.ErrServerError) {
+			t.Fatalf("got %v, want a server error", err)
+		}
+	}
+	if _, err := c.GetRaw(ctx, "teams/1", nil, client.WithNoCache()); !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	// Other groups aren't affected
+	if state := breakerState(c, client.EndpointGroupLive); state != client.BreakerClosed {
+		t.Errorf("live breaker is %s", state)
+	}
+
+	status.Store(http.StatusOK)
+	clock.Advance(time.Minute)
+	if _, err := c.GetRaw(ctx, "teams/1", nil, client.WithNoCache()); err != nil {
+		t.Fatalf("trial request failed: %v", err)
+	}
+	if state := breakerState(c, client.EndpointGroupStatic); state != client.BreakerClosed {
+		t.Errorf("breaker is %s after a successful trial, want closed", state)
+	}
+}
+
+func TestBreakerIgnoresAuthFailures(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:    1,
+		CircuitBreaker: client.CircuitBreaker{FailureThreshold: 2, OpenSeconds: 60},
+	}, api.URL)
+
+	for range 5 {
+		var apiErr *client.APIError
+		if _, err := c.GetRaw(context.Background(), "teams/1", nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("got %v, want a 401", err)
+		}
+	}
+	if calls := api.calls.Load(); calls != 5 {
+		t.Errorf("got %d calls, want 5", calls)
+	}
+	if state := breakerState(c, client.EndpointGroupStatic); state != client.BreakerClosed {
+		t.Errorf("breaker is %s, want closed", state)
+	}
+}
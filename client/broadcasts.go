@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GetBroadcastersByEvent returns where an event can be watched, grouped by country
+func (c *VSportsClient_s) GetBroadcastersByEvent(ctx context.Context, eventID int, opts ...RequestOption) ([]BroadcastListing, error) {
+	endpoint := fmt.Sprintf("broadcasts/by/event/%d", eventID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var broadcasts []Broadcast
+	if err := c.decodeResponse(endpoint, body, &broadcasts); err != nil {
+		return nil, err
+	}
+	return GroupBroadcastsByCountry(broadcasts), nil
+}
+
+// GetBroadcastersByTournament returns where the events of a tournament can be watched,
+// grouped by country. Broadcast.EventID tells which event each broadcast is for
+func (c *VSportsClient_s) GetBroadcastersByTournament(ctx context.Context, tournamentID int, opts ...RequestOption) ([]BroadcastListing, error) {
+	endpoint := fmt.Sprintf("broadcasts/by/tournament/%d", tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var broadcasts []Broadcast
+	if err := c.decodeResponse(endpoint, body, &broadcasts); err != nil {
+		return nil, err
+	}
+	return GroupBroadcastsByCountry(broadcasts), nil
+}
+
+// GroupBroadcastsByCountry groups broadcasts by country, sorted by country code
+// Broadcasts without a country are grouped under an empty Country, listed first
+func GroupBroadcastsByCountry(broadcasts []Broadcast) []BroadcastListing {
+	byCountry := map[string]*BroadcastListing{}
+	var codes []string
+	for _, broadcast := range broadcasts {
+		code := broadcast.Country.Alpha2
+		listing, ok := byCountry[code]
+		if !ok {
+			listing = &BroadcastListing{Country: broadcast.Country}
+			byCountry[code] = listing
+			codes = append(codes, code)
+		}
+		listing.Broadcasts = append(listing.Broadcasts, broadcast)
+	}
+
+	sort.Strings(codes)
+	listings := make([]BroadcastListing, 0, len(codes))
+	for _, code := range codes {
+		listings = append(listings, *byCountry[code])
+	}
+	return listings
+}
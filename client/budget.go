@@ -0,0 +1,107 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when the hard cap on upstream calls has been reached
+// and the response isn't available in the cache
+var ErrBudgetExceeded = errors.New("upstream call budget exceeded")
+
+// BudgetWindow is the usage of one of the hard caps on upstream calls
+type BudgetWindow struct {
+	Limit int       `json:"limit"`
+	Used  int       `json:"used"`
+	Reset time.Time `json:"reset"`
+}
+
+// BudgetStatus is the usage of the configured hard caps
+// Windows without a cap are nil
+type BudgetStatus struct {
+	Hour *BudgetWindow `json:"hour,omitempty"`
+	Day  *BudgetWindow `json:"day,omitempty"`
+}
+
+// A fixed window counter, aligned to the clock (hours start at :00, days at midnight UTC)
+type budgetWindow struct {
+	length time.Duration
+	limit  int
+	used   int
+	start  time.Time
+}
+
+func (w *budgetWindow) roll(now time.Time) {
+	if start := now.UTC().Truncate(w.length); !start.Equal(w.start) {
+		w.start = start
+		w.used = 0
+	}
+}
+
+func (w *budgetWindow) status() *BudgetWindow {
+	return &BudgetWindow{Limit: w.limit, Used: w.used, Reset: w.start.Add(w.length)}
+}
+
+// Hard cap on the number of upstream calls per hour and per day
+// Every call made counts, whether it succeeds or not, since the provider bills them all
+type callBudget struct {
+	mu   sync.Mutex
+	hour *budgetWindow
+	day  *budgetWindow
+}
+
+func newCallBudget(perHour, perDay int) *callBudget {
+	b := &callBudget{}
+	if perHour > 0 {
+		b.hour = &budgetWindow{length: time.Hour, limit: perHour}
+	}
+	if perDay > 0 {
+		b.day = &budgetWindow{length: 24 * time.Hour, limit: perDay}
+	}
+	return b
+}
+
+// Takes one call from the budget, returning false if a cap has been reached
+func (b *callBudget) take(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	windows := []*budgetWindow{b.hour, b.day}
+	for _, w := range windows {
+		if w == nil {
+			continue
+		}
+		w.roll(now)
+		if w.used >= w.limit {
+			return false
+		}
+	}
+	for _, w := range windows {
+		if w != nil {
+			w.used++
+		}
+	}
+	return true
+}
+
+func (b *callBudget) status(now time.Time) BudgetStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var status BudgetStatus
+	if b.hour != nil {
+		b.hour.roll(now)
+		status.Hour = b.hour.status()
+	}
+	if b.day != nil {
+		b.day.roll(now)
+		status.Day = b.day.status()
+	}
+	return status
+}
+
+// BudgetStatus returns how much of the hard caps on upstream calls has been used
+func (c *VSportsClient_s) BudgetStatus() BudgetStatus {
+	return c.budget.status(c.clock.Now())
+}
@@ -0,0 +1,279 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Cache stores the responses of the client, see ClientConfig.CacheBackend
+// Implementations must be safe for concurrent use
+type Cache interface {
+	// Get returns the entry of a key
+	// A missing or expired entry is not an error: found is false and err is nil
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores an entry for ttl, or until it's evicted when ttl is zero
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes entries, missing ones are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Pinger is implemented by caches relying on a server, to check it's reachable
+// The readiness of the client depends on it, see Health
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// KeyScanner is implemented by caches that can list their keys, which the cache
+// inspection and invalidation methods rely on, e.g. InvalidateEvent
+type KeyScanner interface {
+	// Keys returns the keys starting with prefix, in no particular order
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// CacheBackend selects the Cache built from the config
+type CacheBackend string
+
+const (
+	// Responses are cached in Redis, see RedisConfig
+	CacheBackendRedis CacheBackend = "redis"
+	// Responses are cached in the memory of the process, see MemoryCache
+	CacheBackendMemory CacheBackend = "memory"
+	// Responses aren't cached at all
+	CacheBackendNone CacheBackend = "none"
+)
+
+// DefaultCacheMaxEntries is the size of a MemoryCache when CacheMaxEntries isn't set
+const DefaultCacheMaxEntries = 10000
+
+// RedisCache stores the entries in Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a cache on top of a Redis client
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// Keys walks the keyspace with SCAN, so Redis isn't blocked like with KEYS
+func (r *RedisCache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	iter := r.client.Scan(ctx, 0, globEscaper.Replace(prefix)+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
+
+// Escapes the characters with a meaning in the patterns of Redis
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// MemoryCache stores the entries in the memory of the process, evicting the least
+// recently used ones past its size. It's not shared with other processes, so each
+// instance of a service calls the API for itself
+type MemoryCache struct {
+	maxEntries int
+	clock      Clock
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a cache holding up to maxEntries entries
+// Zero or less means DefaultCacheMaxEntries
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		clock:      SystemClock{},
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && !m.clock.Now().Before(entry.expires) {
+		m.removeLocked(element)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	// The caller may reuse its slice
+	entry := &memoryEntry{key: key, value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expires = m.clock.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.maxEntries {
+		m.removeLocked(m.order.Back())
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.removeLocked(element)
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys of the entries that haven't expired
+func (m *MemoryCache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	var keys []string
+	for key, element := range m.entries {
+		expires := element.Value.(*memoryEntry).expires
+		if strings.HasPrefix(key, prefix) && (expires.IsZero() || now.Before(expires)) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Len returns the number of entries, expired ones not yet evicted included
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *MemoryCache) removeLocked(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
+
+// NopCache caches nothing: every lookup misses and every entry is dropped
+type NopCache struct{}
+
+func (NopCache) Get(ctx context.Context, key string) ([]byte, bool, error) { return nil, false, nil }
+func (NopCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+func (NopCache) Delete(ctx context.Context, keys ...string) error { return nil }
+func (NopCache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+var (
+	_ Cache = (*RedisCache)(nil)
+	_ Cache = (*MemoryCache)(nil)
+	_ Cache = NopCache{}
+
+	_ KeyScanner = (*RedisCache)(nil)
+	_ KeyScanner = (*MemoryCache)(nil)
+	_ KeyScanner = NopCache{}
+)
+
+// Builds the cache selected by the config
+// Only Redis needs a server, and it's pinged so a bad address shows up right away
+func newCache(config ClientConfig, credentials CredentialsProvider, logger *slog.Logger) (Cache, error) {
+	if config.Cache != nil {
+		return config.Cache, nil
+	}
+	switch config.CacheBackend {
+	case CacheBackendMemory:
+		cache := NewMemoryCache(config.CacheMaxEntries)
+		if config.Clock != nil {
+			cache.clock = config.Clock
+		}
+		return cache, nil
+	case CacheBackendNone:
+		return NopCache{}, nil
+	}
+
+	redisOptions := &redis.Options{
+		Addr:     config.RedisConfig.Addr,
+		Password: config.RedisConfig.Password,
+		DB:       config.RedisConfig.DB,
+	}
+	if config.Credentials != nil || config.RedisConfig.PasswordFile != "" {
+		redisOptions.OnConnect = redisCredentialsHook(credentials)
+	}
+	var err error
+	redisOptions.TLSConfig, err = buildTLSConfig(config.RedisConfig.TLSConfig, config.RedisConfig.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Redis TLS: %w", err)
+	}
+	if redisOptions.TLSConfig != nil && redisOptions.TLSConfig.ServerName == "" {
+		redisOptions.TLSConfig.ServerName, _, _ = net.SplitHostPort(config.RedisConfig.Addr)
+	}
+	cache := NewRedisCache(redis.NewClient(redisOptions))
+
+	// When running without cache is allowed, requests bypass the cache until it's reachable
+	if err := cache.Ping(context.Background()); err != nil {
+		if !config.Degraded.AllowWithoutCache {
+			return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		}
+		logger.Warn(fmt.Sprintf("Redis is unreachable, running without cache: %v", err))
+	}
+	return cache, nil
+}
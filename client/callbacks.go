@@ -0,0 +1,32 @@
+package client
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned in place of the result of a user callback that panicked
+type PanicError struct {
+	// Which callback panicked, e.g. "quota warning"
+	Callback string
+	Value    any
+	Stack    []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s callback panicked: %v", e.Callback, e.Value)
+}
+
+// Runs a callback given by the user, recovering from any panic in it
+// The panic is logged with its stack and returned as a *PanicError, so the goroutine
+// running the callback (a request, a prefetch worker, a poller...) carries on
+func (c *VSportsClient_s) safeCall(callback string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{Callback: callback, Value: r, Stack: debug.Stack()}
+			c.logger.Error(fmt.Sprintf("%v\n%s", panicErr, panicErr.Stack))
+			err = panicErr
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"sort"
+)
+
+// SuspensionRules are the disciplinary rules of a competition
+type SuspensionRules struct {
+	// Every this many yellow cards, the player is banned for YellowBanMatches
+	YellowThreshold  int `json:"yellowThreshold"`
+	YellowBanMatches int `json:"yellowBanMatches"`
+	// Ban after a red card, second yellows included
+	RedBanMatches int `json:"redBanMatches"`
+}
+
+// DefaultSuspensionRules is a one match ban every 5 yellow cards and after a red card
+var DefaultSuspensionRules = SuspensionRules{YellowThreshold: 5, YellowBanMatches: 1, RedBanMatches: 1}
+
+// PlayerCards is the disciplinary record of a player over a set of events
+type PlayerCards struct {
+	Player Person `json:"player"`
+	Team   Team   `json:"team"`
+	// A second yellow counts as a red card, and not as a yellow one
+	Yellow int `json:"yellow"`
+	Red    int `json:"red"`
+	// Matches of the ban still to serve. The player misses the next match when above zero
+	BannedMatches int  `json:"bannedMatches"`
+	Suspended     bool `json:"suspended"`
+	// One yellow card away from a ban
+	AtRisk bool `json:"atRisk"`
+}
+
+// AggregateCards adds up the cards of every player over the events, which must be
+// detailed events so they carry their timeline, and works out who is suspended or at
+// risk for the next match of their team. Players are sorted by team, then name
+func AggregateCards(events []Event, rules SuspensionRules) []PlayerCards {
+	events = append([]Event(nil), events...)
+	sortByDate(events)
+
+	players := map[int]*PlayerCards{}
+	for _, event := range events {
+		// Playing a match serves a match of ban
+		for _, record := range players {
+			if record.BannedMatches > 0 && (record.Team.ID == event.TeamA.ID || record.Team.ID == event.TeamB.ID) {
+				record.BannedMatches--
+			}
+		}
+
+		for _, occ := range event.Occurrence {
+			if occ.Player.ID == 0 {
+				continue
+			}
+			record, ok := players[occ.Player.ID]
+			if !ok {
+				record = &PlayerCards{Player: occ.Player, Team: occ.Team}
+				players[occ.Player.ID] = record
+			}
+			switch occ.TypeCode {
+			case OccurrenceYellowCard:
+				record.Yellow++
+				if rules.YellowThreshold > 0 && record.Yellow%rules.YellowThreshold == 0 {
+					record.BannedMatches += rules.YellowBanMatches
+				}
+			case OccurrenceSecondYellowCard, OccurrenceRedCard:
+				if occ.TypeCode == OccurrenceSecondYellowCard && record.Yellow > 0 {
+					// The first yellow of the match is cancelled by the sending off
+					record.Yellow--
+				}
+				record.Red++
+				record.BannedMatches += rules.RedBanMatches
+			}
+		}
+	}
+
+	cards := make([]PlayerCards, 0, len(players))
+	for _, record := range players {
+		if record.Yellow == 0 && record.Red == 0 {
+			continue
+		}
+		record.Suspended = record.BannedMatches > 0
+		record.AtRisk = rules.YellowThreshold > 0 && !record.Suspended && record.Yellow%rules.YellowThreshold == rules.YellowThreshold-1
+		cards = append(cards, *record)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Team.Name != cards[j].Team.Name {
+			return cards[i].Team.Name < cards[j].Team.Name
+		}
+		return cards[i].Player.LastName+cards[i].Player.FirstName < cards[j].Player.LastName+cards[j].Player.FirstName
+	})
+	return cards
+}
+
+// GetCardsByTournament aggregates the cards of the tournament's events between two dates
+// with AggregateCards. The dates should cover the whole season for the bans to be right
+func (c *VSportsClient_s) GetCardsByTournament(ctx context.Context, tournamentID int, startDate, endDate Date, rules SuspensionRules, opts ...RequestOption) ([]PlayerCards, error) {
+	events, err := c.tournamentEventsDetailed(ctx, tournamentID, startDate, endDate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return AggregateCards(events, rules), nil
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ChangeKind is the kind of an EventChange
+type ChangeKind string
+
+const (
+	// The status of the event changed, e.g. from scheduled to live
+	ChangeStatus ChangeKind = "status"
+	// The score changed, penalties of a shootout included
+	ChangeScore ChangeKind = "score"
+	// An occurrence was added to the timeline, e.g. a goal or a red card
+	ChangeOccurrenceAdded ChangeKind = "occurrence-added"
+	// An occurrence of the timeline was corrected, e.g. the scorer of a goal
+	ChangeOccurrenceUpdated ChangeKind = "occurrence-updated"
+	// An occurrence left the timeline, e.g. a goal disallowed by the VAR
+	ChangeOccurrenceRemoved ChangeKind = "occurrence-removed"
+	// A medium was added to an occurrence, e.g. the video of a goal
+	ChangeMediaAdded ChangeKind = "media-added"
+	// The lineup of a team was announced
+	ChangeLineupAnnounced ChangeKind = "lineup-announced"
+	// A player joined or left the announced lineup of a team
+	ChangeLineupPlayerAdded   ChangeKind = "lineup-player-added"
+	ChangeLineupPlayerRemoved ChangeKind = "lineup-player-removed"
+)
+
+// Score is the score of an event, as in its Total and PS fields
+type Score struct {
+	TeamA int `json:"teamA"`
+	TeamB int `json:"teamB"`
+	// Penalties of the shootout, if any
+	PenaltiesA int `json:"penaltiesA,omitempty"`
+	PenaltiesB int `json:"penaltiesB,omitempty"`
+}
+
+// Score returns the score of the event
+func (e Event) Score() Score {
+	return Score{TeamA: e.Total_A, TeamB: e.Total_B, PenaltiesA: e.PS_A, PenaltiesB: e.PS_B}
+}
+
+// EventChange is a difference between two versions of an event, e.g. for notifications
+// Only the fields of its kind are set
+type EventChange struct {
+	Kind    ChangeKind `json:"kind"`
+	EventID int        `json:"eventId"`
+	// Status changes
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	Status         string `json:"status,omitempty"`
+	// Score changes
+	PreviousScore *Score `json:"previousScore,omitempty"`
+	Score         *Score `json:"score,omitempty"`
+	// Occurrence changes, with the occurrence as it is now, or as it was last for removed
+	// ones, and media changes, with the occurrence of the medium
+	Occurrence *Occurrence `json:"occurrence,omitempty"`
+	// Updated occurrences, as they were
+	PreviousOccurrence *Occurrence `json:"previousOccurrence,omitempty"`
+	Media              *Media_s    `json:"media,omitempty"`
+	// Lineup changes
+	Team   *Team        `json:"team,omitempty"`
+	Player *SquadMember `json:"player,omitempty"`
+}
+
+// IsGoal tells if the change is a goal being scored, of any type
+func (c EventChange) IsGoal() bool {
+	return c.Kind == ChangeOccurrenceAdded && c.Occurrence.IsGoal()
+}
+
+// IsRedCard tells if the change is a player being sent off, on a second yellow card or not
+func (c EventChange) IsRedCard() bool {
+	if c.Kind != ChangeOccurrenceAdded {
+		return false
+	}
+	return c.Occurrence.TypeCode == OccurrenceRedCard || c.Occurrence.TypeCode == OccurrenceSecondYellowCard
+}
+
+// DiffEvents returns what changed from the previous version of an event to the current
+// one: its status, its score, the occurrences of its timeline and their media. Only
+// detailed events, or those of GetEventOccurrences, carry their timeline
+// Changes come in that order, the occurrences in the order of the timeline
+func DiffEvents(previous, current Event) []EventChange {
+	var changes []EventChange
+	if previous.Status != current.Status {
+		changes = append(changes, EventChange{Kind: ChangeStatus, EventID: current.ID, PreviousStatus: previous.Status, Status: current.Status})
+	}
+	if before, now := previous.Score(), current.Score(); before != now {
+		changes = append(changes, EventChange{Kind: ChangeScore, EventID: current.ID, PreviousScore: &before, Score: &now})
+	}
+
+	before := map[string]Occurrence{}
+	for _, occ := range previous.Occurrence {
+		before[occurrenceKey(occ)] = occ
+	}
+	var media []EventChange
+	seen := map[string]bool{}
+	for _, occ := range current.Occurrence {
+		key := occurrenceKey(occ)
+		seen[key] = true
+		old, found := before[key]
+		switch {
+		case !found:
+			changes = append(changes, EventChange{Kind: ChangeOccurrenceAdded, EventID: current.ID, Occurrence: &occ})
+		case !sameOccurrence(old, occ):
+			changes = append(changes, EventChange{Kind: ChangeOccurrenceUpdated, EventID: current.ID, Occurrence: &occ, PreviousOccurrence: &old})
+		}
+
+		known := map[string]bool{}
+		for _, m := range old.Media {
+			known[mediaKey(m)] = true
+		}
+		for _, m := range occ.Media {
+			if !known[mediaKey(m)] {
+				media = append(media, EventChange{Kind: ChangeMediaAdded, EventID: current.ID, Occurrence: &occ, Media: &m})
+			}
+		}
+	}
+	for _, occ := range previous.Occurrence {
+		if !seen[occurrenceKey(occ)] {
+			changes = append(changes, EventChange{Kind: ChangeOccurrenceRemoved, EventID: current.ID, Occurrence: &occ})
+		}
+	}
+	return append(changes, media...)
+}
+
+// Tells if two versions of an occurrence are the same, their media aside
+func sameOccurrence(a, b Occurrence) bool {
+	a.Media, b.Media = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+// DiffLineups returns what changed from the previous lineups of an event to the current
+// ones: the lineups announced since, then the players who joined or left them. Either
+// lineups may be nil, when they weren't announced
+func DiffLineups(event Event, previous, current *Lineup) []EventChange {
+	if previous == nil {
+		previous = &Lineup{}
+	}
+	if current == nil {
+		current = &Lineup{}
+	}
+	var changes []EventChange
+	diff := func(team Team, before, now []SquadMember) {
+		if len(before) == 0 {
+			if len(now) > 0 {
+				changes = append(changes, EventChange{Kind: ChangeLineupAnnounced, EventID: event.ID, Team: &team})
+			}
+			return
+		}
+		was, is := map[int]bool{}, map[int]bool{}
+		for _, player := range before {
+			was[player.ID] = true
+		}
+		for _, player := range now {
+			is[player.ID] = true
+			if !was[player.ID] {
+				changes = append(changes, EventChange{Kind: ChangeLineupPlayerAdded, EventID: event.ID, Team: &team, Player: &player})
+			}
+		}
+		for _, player := range before {
+			if !is[player.ID] {
+				changes = append(changes, EventChange{Kind: ChangeLineupPlayerRemoved, EventID: event.ID, Team: &team, Player: &player})
+			}
+		}
+	}
+	diff(event.TeamA, previous.TeamALineup, current.TeamALineup)
+	diff(event.TeamB, previous.TeamBLineup, current.TeamBLineup)
+	return changes
+}
+
+// ChangeDetector turns the events of a poll loop into a feed of changes, e.g. polling
+// GetEventsDetailedByDate for the events of the day. It remembers the version of every
+// event it observed last, until it's forgotten. It's safe for concurrent use
+type ChangeDetector struct {
+	mu     sync.Mutex
+	events map[int]Event
+}
+
+func NewChangeDetector() *ChangeDetector {
+	return &ChangeDetector{events: map[int]Event{}}
+}
+
+// Observe returns the changes of the events since they were last observed, in the
+// order of the events, see DiffEvents. Events observed for the first time have none
+func (d *ChangeDetector) Observe(events ...Event) []EventChange {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var changes []EventChange
+	for _, event := range events {
+		if previous, found := d.events[event.ID]; found {
+			changes = append(changes, DiffEvents(previous, event)...)
+		}
+		d.events[event.ID] = event
+	}
+	return changes
+}
+
+// Forget drops the events, e.g. once they're over, so they no longer take memory
+func (d *ChangeDetector) Forget(eventIDs ...int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, id := range eventIDs {
+		delete(d.events, id)
+	}
+}
@@ -2,29 +2,143 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
 )
 
+// RedisConfig holds the settings of the Redis connection used for caching
+// Setting TLS or TLSConfig (code only) enables TLS for the connection
 type RedisConfig struct {
-	Addr     string `json:"addr"`
-	Password string `json:"password"`
-	DB       int    `json:"db"`
+	Addr         string      `json:"addr"`
+	Password     string      `json:"password"`
+	PasswordFile string      `json:"passwordFile"`
+	DB           int         `json:"db"`
+	TLS          *TLSOptions `json:"tls"`
+	TLSConfig    *tls.Config `json:"-"`
 }
 
+// ClientConfig holds the settings used to build a client
+// TimeoutSeconds and CacheDuration are in seconds. Zero values are replaced by the
+// defaults in config.go (so a zero timeout does not mean "no timeout"), and negative
+// values are rejected by the constructor
 type ClientConfig struct {
 	APIKey         string      `json:"apiKey"`
 	TimeoutSeconds int         `json:"timeoutSeconds"`
 	RedisConfig    RedisConfig `json:"redisConfig"`
 	CacheDuration  int         `json:"cacheDuration"`
+	// Where responses are cached, Redis when empty. RedisConfig is only used for Redis
+	CacheBackend CacheBackend `json:"cacheBackend"`
+	// Size of the memory cache, defaults to DefaultCacheMaxEntries
+	CacheMaxEntries int `json:"cacheMaxEntries"`
+	// Can only be set from code, and takes precedence over CacheBackend
+	Cache         Cache `json:"-"`
+	MaxMediaBytes int64 `json:"maxMediaBytes"`
+	// How long match previews are cached, in seconds, see GetMatchPreview
+	PreviewCacheDuration int `json:"previewCacheDuration"`
+	// How long data of past seasons is cached, in seconds, see season.go
+	HistoricalCacheDuration int `json:"historicalCacheDuration"`
+	// Time between the polls of WatchEvent while the event is live, and before it starts,
+	// in seconds, for the watches whose WatchOptions leave them unset
+	WatchIntervalSeconds     int `json:"watchIntervalSeconds"`
+	WatchIdleIntervalSeconds int `json:"watchIdleIntervalSeconds"`
+
+	// Tag goroutines doing vsports work with pprof labels
+	ProfilingLabels bool `json:"profilingLabels"`
+
+	// Pool of keys used along with APIKey, and how requests are spread over them
+	APIKeys     []string    `json:"apiKeys"`
+	KeyStrategy KeyStrategy `json:"keyStrategy"`
+
+	// File holding the API key, re-read when it changes
+	APIKeyFile string `json:"apiKeyFile"`
+	// Can only be set from code, and takes precedence over the keys and files above
+	Credentials CredentialsProvider `json:"-"`
+	// When set, access tokens are obtained with the OAuth2 client credentials flow instead
+	OAuth2 *OAuth2Config `json:"oauth2"`
+
+	// http, https or socks5 proxy. When empty, the proxy environment variables are used
+	ProxyURL string `json:"proxyUrl"`
+	// TLSConfig can only be set from code, and takes precedence over TLS
+	TLS       *TLSOptions `json:"tls"`
+	TLSConfig *tls.Config `json:"-"`
+	// Used for the API requests instead of one built from ProxyURL and TLS (code only)
+	HTTPClient *http.Client `json:"-"`
+	// Sent with every request, defaults to DefaultUserAgent
+	UserAgent string `json:"userAgent"`
+	// Applied to every request, for partner tiers requiring signed requests (code only)
+	Signer Signer `json:"-"`
+
+	// Upstream calls are counted in windows of this many minutes, one hour by default
+	UsageWindowMinutes     int       `json:"usageWindowMinutes"`
+	QuotaWarningThresholds []float64 `json:"quotaWarningThresholds"`
+
+	// Base URLs of the API in order of preference, e.g. the primary and its mirror
+	// Defaults to DefaultBaseURL
+	BaseURLs []string `json:"baseUrls"`
+
+	// Hard caps on upstream calls. Once reached, only cached responses are served
+	MaxCallsPerHour int `json:"maxCallsPerHour"`
+	MaxCallsPerDay  int `json:"maxCallsPerDay"`
+	// Cap on retries as a share of the requests, see RetryBudget
+	RetryBudget RetryBudget `json:"retryBudget"`
+	// Per endpoint group circuit breakers, see CircuitBreaker
+	CircuitBreaker CircuitBreaker `json:"circuitBreaker"`
+	// Pacing of upstream calls, see RateLimit
+	RateLimit RateLimit `json:"rateLimit"`
+	// Attempts of a request failing with a 429, a server error or a network error, on
+	// top of the failover to the other base URLs. Defaults to DefaultMaxAttempts, and 1
+	// disables the retries. See WithRetries to change it for one request
+	MaxAttempts int `json:"maxAttempts"`
+	// How long, in seconds, responses past their cache duration are still served while
+	// they're refreshed in the background. Zero disables it
+	StaleWhileRevalidateSeconds int `json:"staleWhileRevalidateSeconds"`
+	// How long, in seconds, responses the API sent an ETag or a Last-Modified with are
+	// kept past their cache duration, to be revalidated with a conditional request rather
+	// than fetched again: a 304 Not Modified renews them. Zero disables it, though
+	// responses refreshed while still cached are always revalidated
+	ConditionalWindowSeconds int `json:"conditionalWindowSeconds"`
+	// Wait between retries, and how long a failing base URL stays out of rotation (code only)
+	// Default to DefaultRetryBackoff and DefaultFailoverBackoff
+	RetryBackoff    Backoff `json:"-"`
+	FailoverBackoff Backoff `json:"-"`
+
+	// Receives a record of every request, for usage reporting (code only)
+	AuditSink AuditSink `json:"-"`
+
+	// Language requested from the API (sent as Accept-Language), e.g. "pt" or "en"
+	Locale string `json:"locale"`
+	// Prefix keeping this client's cache entries apart from other clients sharing the Redis server
+	CacheNamespace string `json:"cacheNamespace"`
+
+	// Minimum level of the client logs: "debug", "info", "warn" or "error"
+	// When empty, everything is passed to the logger given to the client
+	LogLevel string `json:"logLevel"`
+	// Log the timing and redacted headers of every upstream request, not only of the
+	// ones made with a WithDebug context, see DebugTransport
+	DebugRequests bool `json:"debugRequests"`
+
+	// What to do when Redis or the API are down, see DegradedPolicy
+	Degraded DegradedPolicy `json:"degraded"`
+
+	// Checking of upstream responses against the JSON Schemas of the endpoints, off by default
+	SchemaValidation SchemaValidation `json:"schemaValidation"`
+	// Fail decoding responses with fields the models don't know about, see DecodeError
+	StrictDecoding bool `json:"strictDecoding"`
+
+	// Source of time of the client, for tests (code only). Defaults to SystemClock
+	Clock Clock `json:"-"`
 }
 
 // No-op logger implementation
@@ -40,12 +154,47 @@ func (l *noopLogger) WithGroup(name string) slog.Handler                 { retur
 // VSportsClient_s is the main client struct
 // This is the struct that will be used to interact with the API
 type VSportsClient_s struct {
-	apiKey        string
-	baseURL       string
-	client        *http.Client
-	redisClient   *redis.Client
-	cacheDuration time.Duration
-	logger        *slog.Logger
+	keys      *keyHolder
+	endpoints *endpointPool
+	client    *http.Client
+	cache     Cache
+	logger    *slog.Logger
+	settings  *settings
+	logLevel  *slog.LevelVar
+	// Cache duration of a client derived with WithCacheTTL, overriding the settings
+	cacheTTL time.Duration
+
+	profilingLabels bool
+	profilingHook   ProfilingHook
+	signer          Signer
+	usage           *usageTracker
+	rateLimit       *rateLimitTracker
+	budget          *callBudget
+	limiter         *rateLimiter
+	retries         *retryBudget
+	retryBackoff    Backoff
+	maxAttempts     int
+	breakers        *breakers
+	auditSink       AuditSink
+	locale          string
+	userAgent       string
+	cacheNamespace  string
+	degraded        DegradedPolicy
+	clock           Clock
+	enrichers       []Enricher
+	observers       []Observer
+	schemas         *schemaTracker
+	deprecations    *deprecationTracker
+
+	// Expired responses are served while they're refreshed, once per key at a time
+	staleWhileRevalidate time.Duration
+	revalidating         *keySet
+	conditionalWindow    time.Duration
+
+	// Identical requests in flight, see shareRequest
+	inflight *singleflight.Group
+
+	strictDecoding bool
 }
 
 // VSportsClient is the constructor for the VSportsClient_s struct
@@ -56,33 +205,214 @@ func VSportsClient(config ClientConfig, logger *slog.Logger) (*VSportsClient_s,
 		logger = slog.New(&noopLogger{}) // Use no-op logger if nil
 	}
 
-	// Create a new Redis client
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     config.RedisConfig.Addr,
-		Password: config.RedisConfig.Password,
-		DB:       config.RedisConfig.DB,
-	})
+	// Fill in the defaults and refuse configs that would produce a broken client
+	config = config.WithDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
-	// Ping the Redis server to check if the connection is established
-	_, err := rdb.Ping(context.Background()).Result()
+	shared, err := newSharedResources(config, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		return nil, err
+	}
+	return newClient(config, logger, shared)
+}
+
+// The connections and limits a client can share with others
+// Clients created by a ClientManager share one set, standalone clients get their own
+type sharedResources struct {
+	httpClient  *http.Client
+	cache       Cache
+	endpoints   *endpointPool
+	budget      *callBudget
+	limiter     *rateLimiter
+	retries     *retryBudget
+	breakers    *breakers
+	credentials CredentialsProvider
+}
+
+func newSharedResources(config ClientConfig, logger *slog.Logger) (*sharedResources, error) {
+	// Secrets mounted as files are watched for changes
+	credentials := config.Credentials
+	if credentials == nil && (config.APIKeyFile != "" || config.RedisConfig.PasswordFile != "") {
+		files := NewFileCredentials(config.APIKeyFile, config.RedisConfig.PasswordFile)
+		if config.Clock != nil {
+			files.Clock = config.Clock
+		}
+		credentials = files
+	}
+
+	// All requests go through the same transport, proxy settings included
+	// Timeouts are applied per request rather than on the http.Client,
+	// so they can be changed while the client is running
+	// Requests made with a WithDebug context are logged by the debug transport
+	var httpClient *http.Client
+	if config.HTTPClient != nil {
+		// A copy, so the caller's client is left as it is
+		copied := *config.HTTPClient
+		base := copied.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		copied.Transport = &DebugTransport{Base: base, Logger: logger, All: config.DebugRequests}
+		httpClient = &copied
+	} else {
+		transport, err := newTransport(config)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{Transport: &DebugTransport{Base: transport, Logger: logger, All: config.DebugRequests}}
+	}
+
+	cache, err := newCache(config, credentials, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharedResources{
+		httpClient:  httpClient,
+		cache:       cache,
+		endpoints:   newEndpointPool(config.BaseURLs, config.FailoverBackoff),
+		budget:      newCallBudget(config.MaxCallsPerHour, config.MaxCallsPerDay),
+		limiter:     newRateLimiter(config.RateLimit),
+		retries:     newRetryBudget(config.RetryBudget),
+		breakers:    newBreakers(config.CircuitBreaker),
+		credentials: credentials,
+	}, nil
+}
+
+// Builds a client on top of the given shared resources
+// The config must already be validated
+func newClient(config ClientConfig, logger *slog.Logger, shared *sharedResources) (*VSportsClient_s, error) {
+	clock := config.Clock
+	if clock == nil {
+		clock = SystemClock{}
 	}
 
+	// A pool of keys takes over from the single key
+	var keyProvider KeyProvider = StaticKey(config.APIKey)
+	if len(config.APIKeys) > 0 {
+		keys := config.APIKeys
+		if config.APIKey != "" && !slices.Contains(keys, config.APIKey) {
+			keys = append([]string{config.APIKey}, keys...)
+		}
+		pool, err := NewKeyPool(keys, config.KeyStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		pool.clock = clock
+		keyProvider = pool
+	}
+	if config.Credentials != nil || config.APIKeyFile != "" {
+		keyProvider = credentialsKeyProvider{shared.credentials}
+	}
+
+	// OAuth2 access tokens replace the static key altogether
+	if config.OAuth2 != nil {
+		tokenClient := &http.Client{
+			Transport: shared.httpClient.Transport,
+			Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
+		}
+		tokenSource := NewOAuth2TokenSource(*config.OAuth2, tokenClient)
+		tokenSource.clock = clock
+		keyProvider = tokenSource
+	}
+
+	// The log level can be changed at runtime with UpdateConfig
+	level, err := parseLogLevel(config.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	logLevel := &slog.LevelVar{}
+	logLevel.Set(level)
+	logger = slog.New(&levelHandler{level: logLevel, handler: logger.Handler()})
+
 	return &VSportsClient_s{
-		apiKey:        config.APIKey,
-		baseURL:       "https://extended.vsports.pt/api",
-		client:        &http.Client{Timeout: time.Duration(config.TimeoutSeconds) * time.Second},
-		redisClient:   rdb,
-		cacheDuration: time.Duration(config.CacheDuration) * time.Second,
-		logger:        logger,
+		keys:      newKeyHolder(keyProvider),
+		endpoints: shared.endpoints,
+		client:    shared.httpClient,
+		cache:     shared.cache,
+		logger:    logger,
+		settings:  newSettings(config),
+		logLevel:  logLevel,
+
+		profilingLabels: config.ProfilingLabels,
+		signer:          signerWithClock(config.Signer, clock),
+		usage:           newUsageTracker(time.Duration(config.UsageWindowMinutes)*time.Minute, config.QuotaWarningThresholds),
+		rateLimit:       &rateLimitTracker{},
+		budget:          shared.budget,
+		limiter:         shared.limiter,
+		retries:         shared.retries,
+		breakers:        shared.breakers,
+		retryBackoff:    config.RetryBackoff,
+		maxAttempts:     config.MaxAttempts,
+		auditSink:       config.AuditSink,
+		locale:          config.Locale,
+		userAgent:       config.UserAgent,
+		cacheNamespace:  config.CacheNamespace,
+		degraded:        config.Degraded,
+		clock:           clock,
+		schemas:         newSchemaTracker(config.SchemaValidation),
+		deprecations:    newDeprecationTracker(),
+
+		staleWhileRevalidate: time.Duration(config.StaleWhileRevalidateSeconds) * time.Second,
+		revalidating:         newKeySet(),
+		conditionalWindow:    time.Duration(config.ConditionalWindowSeconds) * time.Second,
+
+		inflight:       &singleflight.Group{},
+		strictDecoding: config.StrictDecoding,
 	}, nil
 }
 
 // A generic request handler for all API requests
-// It can deal with query parameters and caching
-func (c *VSportsClient_s) request(endpoint string, params map[string]string, useCache bool) ([]byte, error) {
-	ctx := context.Background()
+// It can deal with query parameters, request options and caching. The request, the cache
+// lookups and the cache writes are all cancelled along with ctx
+func (c *VSportsClient_s) request(ctx context.Context, endpoint string, params map[string]string, opts ...RequestOption) (body []byte, err error) {
+	return c.requestTTL(ctx, endpoint, params, 0, opts...)
+}
+
+// Same as request, caching the response for the given duration instead of CacheDuration
+// A zero ttl means CacheDuration. A ttl set by the options wins
+func (c *VSportsClient_s) requestTTL(ctx context.Context, endpoint string, params map[string]string, ttl time.Duration, opts ...RequestOption) (body []byte, err error) {
+	o := applyOptions(params, opts)
+	if o.ttl > 0 {
+		ttl = o.ttl
+	}
+	c.profile(ctx, SubsystemAPI, endpoint, func(ctx context.Context) {
+		body, err = c.doRequest(ctx, endpoint, o, ttl)
+	})
+	if err == nil && o.raw != nil {
+		*o.raw = rawResponse(body)
+	}
+	return body, err
+}
+
+func (c *VSportsClient_s) doRequest(ctx context.Context, endpoint string, o requestOptions, ttl time.Duration) (body []byte, err error) {
+	params := o.params
+	useCache := !o.noCache
+	// Keep a record of the request for the audit log, if enabled
+	record := AuditRecord{Time: c.clock.Now(), Endpoint: endpoint, Params: params, Cache: AuditCacheBypass}
+	if useCache {
+		record.Cache = AuditCacheMiss
+	}
+	if c.auditSink != nil {
+		defer func() { c.audit(record, err) }()
+	}
+	info := requestInfo(endpoint, params)
+	if len(c.observers) > 0 {
+		ctx = c.observeRequestStart(ctx, info)
+		defer func() {
+			result := RequestResult{
+				Cache:    record.Cache,
+				Upstream: record.Upstream,
+				Status:   record.Status,
+				Bytes:    record.Bytes,
+				Duration: c.clock.Now().Sub(record.Time),
+				Err:      err,
+			}
+			c.observe(func(o Observer) { o.OnRequestEnd(ctx, info, result) })
+		}()
+	}
 
 	// Sort and serialize params
 	// They need to be sorted to be consistant with any order of the parameters called
@@ -95,28 +425,238 @@ func (c *VSportsClient_s) request(endpoint string, params map[string]string, use
 	serializedParams := strings.Join(sortedParams, "&")
 
 	// Use a namespace for the cache key for protection against cache pollution
-	cacheKey := fmt.Sprintf("vsports://%s:%s", endpoint, serializedParams)
+	cacheKey := c.cacheKey(endpoint, serializedParams)
 
 	// Check if the cache is enabled and if the key exists
 	// If so, immediately return the cached response
-	if useCache {
-		cachedResponse, err := c.redisClient.Get(ctx, cacheKey).Result()
-		if err == nil {
+	var cacheErr error
+	if useCache && o.refresh {
+		record.Cache = AuditCacheRefresh
+		// The cached copy may only need renewing
+		if cached, found, _ := c.cacheLookup(ctx, cacheKey); found && !cached.validators.empty() {
+			o.revalidate = &cached
+		}
+	} else if useCache {
+		var cached cacheEntry
+		var found bool
+		cached, found, cacheErr = c.cacheLookup(ctx, cacheKey)
+		expired := found && cached.expired(c.clock.Now())
+		switch {
+		// Past its freshness, the response is still served while a fresh one is fetched
+		case expired && c.staleWhileRevalidate > 0:
+			c.logger.Debug(fmt.Sprintf("Using expired response for %s while revalidating it", cacheKey))
+			record.Cache = AuditCacheRevalidate
+			c.revalidate(ctx, cacheKey, endpoint, o, ttl)
+		// Kept past its freshness for a conditional request, see ConditionalWindowSeconds
+		case expired && !cached.validators.empty():
+			c.logger.Debug(fmt.Sprintf("Revalidating expired response for %s", cacheKey))
+			o.revalidate = &cached
+			found = false
+		case found:
 			c.logger.Debug(fmt.Sprintf("Using cached response for %s", cacheKey))
-			return []byte(cachedResponse), nil
+			record.Cache = AuditCacheHit
+		}
+		if found {
+			c.observe(func(o Observer) { o.OnCacheHit(ctx, info) })
+			return cached.body, nil
+		}
+		c.observe(func(o Observer) { o.OnCacheMiss(ctx, info) })
+		if cacheErr != nil {
+			c.logger.Warn(fmt.Sprintf("Cache unavailable for %s, bypassing it: %v", cacheKey, cacheErr))
+		} else {
+			c.logger.Debug(fmt.Sprintf("Cache miss for %s", cacheKey))
+		}
+	}
+
+	// Identical requests in flight share a single upstream call
+	fetch := func(ctx context.Context, record *AuditRecord) ([]byte, error) {
+		return c.fetchAndStore(ctx, endpoint, o, useCache, ttl, cacheKey, cacheErr, record)
+	}
+	if key, ok := shareKey(cacheKey, o, ttl); ok {
+		return c.shareRequest(ctx, key, &record, fetch)
+	}
+	return fetch(ctx, &record)
+}
+
+// Calls the API on a cache miss and caches the response, falling back to the last good
+// response when the API is down or the call budget exhausted
+func (c *VSportsClient_s) fetchAndStore(ctx context.Context, endpoint string, o requestOptions, useCache bool, ttl time.Duration, cacheKey string, cacheErr error, record *AuditRecord) ([]byte, error) {
+	// Fail fast while the endpoint group is known to be down, the last good response may do
+	group := EndpointGroup(endpoint)
+	if err := c.breakers.allow(group, c.clock.Now()); err != nil {
+		c.logger.Debug(fmt.Sprintf("Not requesting %s: %v", endpoint, err))
+		stale, staleErr := c.serveStale(ctx, cacheKey, err, cacheErr)
+		if staleErr != nil {
+			return nil, staleErr
+		}
+		record.Cache = AuditCacheStale
+		return stale, nil
+	}
+
+	// Once the hard cap on upstream calls is reached, the cache is the only source left
+	// Use it even if the caller asked to skip it, as stale data beats no data here
+	if !c.budget.take(c.clock.Now()) {
+		c.breakers.release(group)
+		if !useCache {
+			if cachedResponse, found, _ := c.cacheGet(ctx, cacheKey); found {
+				c.logger.Warn(fmt.Sprintf("Call budget exceeded, using cached response for %s", cacheKey))
+				record.Cache = AuditCacheBudgetFallback
+				return cachedResponse, nil
+			}
 		}
-		c.logger.Debug(fmt.Sprintf("Cache miss for %s: %v", cacheKey, err))
+		c.logger.Error(fmt.Sprintf("Call budget exceeded, not requesting %s", endpoint))
+		return nil, ErrBudgetExceeded
 	}
 
 	// So we have a cache miss. Make the request to the API
-	url := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	fetched, err := c.fetchWithRetries(ctx, endpoint, o, record)
+	c.recordBreaker(ctx, group, err)
+	if err != nil {
+		// The API answered, there's nothing stale to fall back to
+		if isDefinitive(err) {
+			return nil, err
+		}
+		// The API is down, the last good response may still do
+		stale, staleErr := c.serveStale(ctx, cacheKey, err, cacheErr)
+		if staleErr != nil {
+			return nil, staleErr
+		}
+		record.Cache = AuditCacheStale
+		return stale, nil
+	}
+
+	// The cached copy is still good, renew it. The API may have sent new validators
+	if fetched.notModified {
+		c.logger.Debug(fmt.Sprintf("Renewing cached response for %s, not modified", cacheKey))
+		record.Cache = AuditCacheNotModified
+		if fetched.validators.empty() {
+			fetched.validators = o.revalidate.validators
+		}
+		if o.validators != nil {
+			*o.validators = fetched.validators
+		}
+		if err := c.cacheStore(ctx, cacheKey, o.revalidate.body, fetched.validators, useCache, ttl); err != nil {
+			return nil, err
+		}
+		return o.revalidate.body, nil
+	}
+
+	// Responses narrowed down with WithFields lack required fields on purpose
+	body := fetched.body
+	if o.params[paramFields] == "" {
+		c.validateSample(endpoint, pageData(body))
+	}
+
+	// It's time to cache the response
+	if err := c.cacheStore(ctx, cacheKey, body, fetched.validators, useCache, ttl); err != nil {
+		return nil, err
+	}
+	if o.validators != nil {
+		*o.validators = fetched.validators
+	}
+
+	return body, nil
+}
+
+// Builds the cache key of a request
+// Clients with a cache namespace or a locale get keys of their own, as their responses differ
+func (c *VSportsClient_s) cacheKey(endpoint, serializedParams string) string {
+	prefix := cacheKeyPrefix
+	if c.cacheNamespace != "" {
+		prefix += c.cacheNamespace + "/"
+	}
+	key := fmt.Sprintf("%s%s:%s", prefix, endpoint, serializedParams)
+	if c.locale != "" {
+		key += "#" + c.locale
+	}
+	return key
+}
+
+// Sends the request to the first healthy base URL, moving on to the next one
+// when a base URL can't be reached or answers with a server error
+// The request is conditional when the validators of the cached copy are given
+func (c *VSportsClient_s) fetchWithFailover(ctx context.Context, endpoint string, params map[string]string, conditional validators, record *AuditRecord) (fetchedResponse, error) {
+	var lastErr error
+	for i, baseURL := range c.endpoints.candidates(c.clock.Now()) {
+		if i > 0 {
+			// Don't start an attempt that can't finish in time
+			if !deadlineAllows(ctx, 0) {
+				c.logger.Debug(fmt.Sprintf("Not failing over to %s, the deadline is too close", baseURL))
+				return fetchedResponse{}, lastErr
+			}
+			// Failing over is a retry, and too many of them would pile onto an outage
+			if !c.retries.allowRetry(c.clock.Now()) {
+				c.logger.Warn(fmt.Sprintf("Retry budget exhausted, not failing over to %s", baseURL))
+				return fetchedResponse{}, fmt.Errorf("%w while failing over: %w", ErrRetryBudgetExhausted, lastErr)
+			}
+			// Every attempt past the first is one more upstream call
+			if !c.budget.take(c.clock.Now()) {
+				return fetchedResponse{}, fmt.Errorf("%w while failing over: %w", ErrBudgetExceeded, lastErr)
+			}
+			c.logger.Warn(fmt.Sprintf("Failing over to %s: %v", baseURL, lastErr))
+			c.observe(func(o Observer) {
+				o.OnRetry(ctx, RetryInfo{Request: requestInfo(endpoint, params), Attempt: i, Reason: RetryFailover, Err: lastErr})
+			})
+		}
+
+		fetched, failover, err := c.fetch(ctx, baseURL, endpoint, params, conditional, record)
+		// fetch dropped a key the API refused, e.g. a revoked OAuth2 token, so another
+		// one may do. Only once, a refused fresh key is refused for good
+		if c.keyRevoked(err) && c.budget.take(c.clock.Now()) {
+			c.logger.Warn(fmt.Sprintf("Retrying %s with a new key: %v", endpoint, err))
+			fetched, failover, err = c.fetch(ctx, baseURL, endpoint, params, conditional, record)
+		}
+		if err == nil {
+			c.endpoints.success(baseURL, c.clock.Now())
+			return fetched, nil
+		}
+		if !failover {
+			return fetchedResponse{}, err
+		}
+		// Running out of time is the caller's doing, not the base URL's
+		if ctx.Err() != nil {
+			return fetchedResponse{}, attemptError(ctx, err, lastErr)
+		}
+		if cooldown, down := c.endpoints.failure(baseURL, err, c.clock.Now()); down {
+			c.logger.Error(fmt.Sprintf("Taking %s out of rotation for %s: %v", baseURL, cooldown.Round(time.Second), err))
+		}
+		lastErr = err
+	}
+	return fetchedResponse{}, lastErr
+}
+
+// Tells if err is the API refusing a key that the key provider can replace, see KeyInvalidator
+func (c *VSportsClient_s) keyRevoked(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	_, ok := c.keys.get().(KeyInvalidator)
+	return ok
+}
+
+// Makes a single request to the API at the given base URL
+// The returned boolean tells whether the failure is the server's fault, so another base URL may do better
+func (c *VSportsClient_s) fetch(ctx context.Context, baseURL, endpoint string, params map[string]string, conditional validators, record *AuditRecord) (_ fetchedResponse, _ bool, err error) {
+	url := fmt.Sprintf("%s/%s", baseURL, endpoint)
 	c.logger.Debug(fmt.Sprintf("Making request to URL: %s", url))
 
+	// Waiting for a turn isn't part of the timeout
+	if err := c.limiter.wait(ctx, c.clock); err != nil {
+		c.logger.Warn(fmt.Sprintf("Request to %s not made: %v", url, err))
+		return fetchedResponse{}, false, err
+	}
+
+	// The timeout covers the whole request, reading the body included
+	timeout, _, _ := c.settings.get()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Create the request
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		c.logger.Error(fmt.Sprintf("Error creating request: %v", err))
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return fetchedResponse{}, false, fmt.Errorf("error creating request: %w", err)
 	}
 
 	// Add the parameters to the request if any
@@ -129,137 +669,261 @@ func (c *VSportsClient_s) request(endpoint string, params map[string]string, use
 	}
 
 	// Add the Authorization header
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	// The key is read once per request, so a rotation never affects a request in flight
+	keyProvider := c.keys.get()
+	apiKey, err := keyProvider.APIKey(ctx)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("Error getting API key: %v", err))
+		return fetchedResponse{}, false, fmt.Errorf("error getting API key: %w", err)
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+	conditional.apply(req)
 
-	// Finally, make the request
+	// Sign the request last, once every header is in place
+	if c.signer != nil {
+		if err := c.signer.Sign(req); err != nil {
+			c.logger.Error(fmt.Sprintf("Error signing request: %v", err))
+			return fetchedResponse{}, false, fmt.Errorf("error signing request: %w", err)
+		}
+	}
+
+	// Finally, make the request, and keep track of it as an attempt of the audit record
+	record.Upstream = true
+	record.Status, record.Bytes = 0, 0
+	attempt := AuditAttempt{Time: c.clock.Now(), BaseURL: baseURL}
+	defer func() {
+		attempt.Status, attempt.Bytes = record.Status, record.Bytes
+		attempt.Duration = c.clock.Now().Sub(attempt.Time)
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+		record.Attempts = append(record.Attempts, attempt)
+	}()
 	resp, err := c.client.Do(req)
 	if err != nil {
+		c.recordUsage(endpoint, nil, true)
 		c.logger.Error(fmt.Sprintf("Error making request: %v", err))
-		return nil, fmt.Errorf("error making request: %w", err)
+		return fetchedResponse{}, true, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordUsage(endpoint, resp, resp.StatusCode >= 400)
+	record.Status = resp.StatusCode
+	c.checkDeprecation(ctx, endpoint, resp)
+
+	// A rejected key may have been revoked upstream, e.g. an expired OAuth2 token
+	if resp.StatusCode == http.StatusUnauthorized {
+		if invalidator, ok := keyProvider.(KeyInvalidator); ok {
+			invalidator.Invalidate(apiKey)
+		}
+	}
+
+	// Keep track of how much is left, and let key pools know about the key we used
+	if state, found := parseRateLimit(resp, c.clock.Now()); found {
+		c.rateLimit.observe(state)
+		if observer, ok := keyProvider.(RateLimitObserver); ok {
+			observer.ObserveRateLimit(apiKey, state)
+		}
+	}
+
+	// Only a conditional request can get a 304, which has no body
+	if resp.StatusCode == http.StatusNotModified && !conditional.empty() {
+		return fetchedResponse{validators: validatorsOf(resp), notModified: true}, false, nil
+	}
 
-	// Read the response body as an array of bytes
-	body, err := io.ReadAll(resp.Body)
+	// Read the response body as an array of bytes, up to a limit so a runaway response can't exhaust memory
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	record.Bytes = len(body)
 	if err != nil {
 		c.logger.Error(fmt.Sprintf("Error reading response body: %v", err))
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return fetchedResponse{}, true, fmt.Errorf("error reading response body: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		c.logger.Error(fmt.Sprintf("Response from %s is over %d bytes", url, maxResponseBytes))
+		return fetchedResponse{}, true, fmt.Errorf("%w: response from %s is over %d bytes", ErrMalformedResponse, baseURL, maxResponseBytes)
 	}
 
-	// If we're using cache, it's time to cache the response
-	if useCache {
-		err = c.redisClient.Set(ctx, cacheKey, body, c.cacheDuration).Err()
-		if err != nil {
-			c.logger.Error(fmt.Sprintf("Error setting cache for %s: %v", cacheKey, err))
-			return nil, fmt.Errorf("error setting cache for %s: %w", cacheKey, err)
+	// Errors must not end up in the cache, and a mirror may be able to answer server errors
+	if resp.StatusCode >= 400 {
+		apiErr := newAPIError(resp, baseURL, endpoint, body, c.clock.Now())
+		if resp.StatusCode >= 500 {
+			c.logger.Error(fmt.Sprintf("Server error from %s: %s", url, resp.Status))
+		} else {
+			c.logger.Warn(fmt.Sprintf("Error from %s: %s", url, resp.Status))
 		}
-		c.logger.Debug(fmt.Sprintf("Cached response for %s", cacheKey))
+		return fetchedResponse{}, resp.StatusCode >= 500, apiErr
 	}
 
-	return body, nil
+	// Neither should truncated or non-JSON answers, e.g. an error page of a proxy
+	if !json.Valid(body) {
+		c.logger.Error(fmt.Sprintf("Invalid JSON from %s", url))
+		return fetchedResponse{}, true, fmt.Errorf("%w: invalid JSON from %s", ErrMalformedResponse, baseURL)
+	}
+
+	// Keep the total of paginated lists along with them, cached or not
+	if total := resp.Header.Get(TotalCountHeader); total != "" && paginated(params) {
+		body = wrapPage(body, total)
+	}
+
+	return fetchedResponse{body: body, validators: validatorsOf(resp)}, false, nil
 }
 
 // ===== API Methods =====
 
-func (c *VSportsClient_s) GetTournaments(useCache bool) ([]Tournament, error) {
-	body, err := c.request("tournaments", nil, useCache)
+// GetRaw returns the undecoded response of any endpoint, e.g. "teams/12"
+// It goes through the same cache, budget and failover as the typed methods
+func (c *VSportsClient_s) GetRaw(ctx context.Context, endpoint string, params map[string]string, opts ...RequestOption) ([]byte, error) {
+	return c.request(ctx, strings.TrimPrefix(endpoint, "/"), params, opts...)
+}
+
+// DoInto requests any endpoint, e.g. "teams/12", and decodes the response into v, which
+// can be a type of the caller's own, with only the fields it needs or extra ones
+// The request goes through the same cache, budget, retries and failover as the typed
+// methods, and is cancelled along with ctx
+func (c *VSportsClient_s) DoInto(ctx context.Context, endpoint string, params map[string]string, v any, opts ...RequestOption) error {
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	body, err := c.request(ctx, endpoint, params, opts...)
+	if err != nil {
+		return err
+	}
+	// Only paginated lists may come in a page envelope
+	if paginated(applyOptions(params, opts).params) {
+		body = unwrapList(body, opts)
+	}
+	return c.decodeResponse(endpoint, body, v)
+}
+
+func (c *VSportsClient_s) GetTournaments(ctx context.Context, opts ...RequestOption) ([]Tournament, error) {
+	body, err := c.request(ctx, "tournaments", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var tournaments []Tournament
-	err = json.Unmarshal(body, &tournaments)
+	err = c.decodeList("tournaments", body, &tournaments, opts)
 	return tournaments, err
 }
 
-func (c *VSportsClient_s) GetTournamentById(tournamentID int, useCache bool) (*Tournament, error) {
-	body, err := c.request(fmt.Sprintf("tournaments/%d", tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetTournamentById(ctx context.Context, tournamentID int, opts ...RequestOption) (*Tournament, error) {
+	endpoint := fmt.Sprintf("tournaments/%d", tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var tournament Tournament
-	err = json.Unmarshal(body, &tournament)
+	err = c.decodeResponse(endpoint, body, &tournament)
 	return &tournament, err
 }
 
-func (c *VSportsClient_s) GetTeamById(teamID int, useCache bool) (*Team, error) {
-	body, err := c.request(fmt.Sprintf("teams/%d", teamID), nil, useCache)
+func (c *VSportsClient_s) GetTeamById(ctx context.Context, teamID int, opts ...RequestOption) (*Team, error) {
+	endpoint := fmt.Sprintf("teams/%d", teamID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var team Team
-	err = json.Unmarshal(body, &team)
+	err = c.decodeResponse(endpoint, body, &team)
 	return &team, err
 }
 
-func (c *VSportsClient_s) GetTeamsByTournamentId(tournamentID int, useCache bool) ([]Team, error) {
-	body, err := c.request(fmt.Sprintf("teams/by/tournament/%d", tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetTeamsByTournamentId(ctx context.Context, tournamentID int, opts ...RequestOption) ([]Team, error) {
+	endpoint := fmt.Sprintf("teams/by/tournament/%d", tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var teams []Team
-	err = json.Unmarshal(body, &teams)
+	err = c.decodeList(endpoint, body, &teams, opts)
 	return teams, err
 }
 
-func (c *VSportsClient_s) GetEventsByDate(startDate string, endDate string, useCache bool) ([]Event, error) {
+// GetEventsByDate returns the events from startDate to endDate, both included
+// The range is checked first, failing with ErrInvalidDateRange without any request
+func (c *VSportsClient_s) GetEventsByDate(ctx context.Context, startDate, endDate Date, opts ...RequestOption) ([]Event, error) {
+	if err := validDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
 	params := map[string]string{
-		"start_date": startDate,
-		"end_date":   endDate,
+		"start_date": startDate.String(),
+		"end_date":   endDate.String(),
 	}
 
-	body, err := c.request("events", params, useCache)
+	body, err := c.request(ctx, "events", params, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var events []Event
-	err = json.Unmarshal(body, &events)
-	return events, err
+	if err := c.decodeList("events", body, &events, opts); err != nil {
+		return nil, err
+	}
+	c.enrich(ctx, events)
+	return events, nil
 }
 
-func (c *VSportsClient_s) GetEventsDetailedByDate(startDate string, endDate string, useCache bool) ([]Event, error) {
+// GetEventsDetailedByDate is GetEventsByDate with the details of every event
+func (c *VSportsClient_s) GetEventsDetailedByDate(ctx context.Context, startDate, endDate Date, opts ...RequestOption) ([]Event, error) {
+	if err := validDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
 	params := map[string]string{
-		"end_date":   endDate,
-		"start_date": startDate,
+		"end_date":   endDate.String(),
+		"start_date": startDate.String(),
 	}
-	body, err := c.request("events/detailed", params, useCache)
+	body, err := c.request(ctx, "events/detailed", params, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var events []Event
-	err = json.Unmarshal(body, &events)
-	return events, err
+	if err := c.decodeList("events/detailed", body, &events, opts); err != nil {
+		return nil, err
+	}
+	c.enrich(ctx, events)
+	return events, nil
 }
 
-func (c *VSportsClient_s) GetEventById(eventID int, useCache bool) (*Event, error) {
-	body, err := c.request(fmt.Sprintf("events/%d", eventID), nil, useCache)
+func (c *VSportsClient_s) GetEventById(ctx context.Context, eventID int, opts ...RequestOption) (*Event, error) {
+	endpoint := fmt.Sprintf("events/%d", eventID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var event Event
-	err = json.Unmarshal(body, &event)
-	return &event, err
+	events := make([]Event, 1)
+	if err := c.decodeResponse(endpoint, body, &events[0]); err != nil {
+		return nil, err
+	}
+	c.enrich(ctx, events)
+	return &events[0], nil
 }
 
-func (c *VSportsClient_s) GetEventDetailed(eventID int, useCache bool) (*Event, error) {
-	body, err := c.request(fmt.Sprintf("events/%d/detailed", eventID), nil, useCache)
+func (c *VSportsClient_s) GetEventDetailed(ctx context.Context, eventID int, opts ...RequestOption) (*Event, error) {
+	endpoint := fmt.Sprintf("events/%d/detailed", eventID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var event Event
-	err = json.Unmarshal(body, &event)
-	return &event, err
+	events := make([]Event, 1)
+	if err := c.decodeResponse(endpoint, body, &events[0]); err != nil {
+		return nil, err
+	}
+	c.enrich(ctx, events)
+	return &events[0], nil
 }
 
-func (c *VSportsClient_s) GetEventOccurrences(eventID string, useCache bool) ([]Event, error) {
-	body, err := c.request(fmt.Sprintf("events/%s/occurrences", eventID), nil, useCache)
+// GetEventOccurrences returns the event with its timeline, as one or more events
+// The occurrences of each event are deduplicated, see MergeOccurrences
+func (c *VSportsClient_s) GetEventOccurrences(ctx context.Context, eventID string, opts ...RequestOption) ([]Event, error) {
+	endpoint := fmt.Sprintf("events/%s/occurrences", eventID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -267,134 +931,247 @@ func (c *VSportsClient_s) GetEventOccurrences(eventID string, useCache bool) ([]
 	// This method may return a single event or an array of events
 	// Ensure we always return an array
 	var response []Event
-	err = json.Unmarshal(body, &response)
+	err = c.decodeResponse(endpoint, body, &response)
 	if err != nil {
 		var singleEvent Event
-		err = json.Unmarshal(body, &singleEvent)
+		err = c.decodeResponse(endpoint, body, &singleEvent)
 		if err != nil {
 			return nil, err
 		}
 		response = append(response, singleEvent)
 	}
+	for i := range response {
+		response[i].Occurrence = MergeOccurrences(response[i].Occurrence)
+	}
 	return response, nil
 
 }
 
-func (c *VSportsClient_s) GetEventMedia(eventID string, useCache bool) ([]Media_s, error) {
-	body, err := c.request(fmt.Sprintf("events/%s/occurrences", eventID), nil, useCache)
+// GetEventMedia returns the media of the occurrences of an event, without duplicates
+func (c *VSportsClient_s) GetEventMedia(ctx context.Context, eventID string, opts ...RequestOption) ([]Media_s, error) {
+	events, err := c.GetEventOccurrences(ctx, eventID, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var event Event
-	err = json.Unmarshal(body, &event)
+	lists := make([][]Occurrence, 0, len(events))
+	for _, event := range events {
+		lists = append(lists, event.Occurrence)
+	}
+	var media [][]Media_s
+	for _, occ := range MergeOccurrences(lists...) {
+		media = append(media, occ.Media)
+	}
+
+	return MergeMedia(media...), nil
+}
+
+func (c *VSportsClient_s) GetPersonById(ctx context.Context, PersonID int, opts ...RequestOption) (*Person, error) {
+	endpoint := fmt.Sprintf("person/%d", PersonID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var media []Media_s
-	for _, occ := range event.Occurrence {
-		media = append(media, occ.Media...)
+	var person Person
+	err = c.decodeResponse(endpoint, body, &person)
+	return &person, err
+}
+
+func (c *VSportsClient_s) GetRefereeById(ctx context.Context, refereeID int, opts ...RequestOption) (*Person, error) {
+	endpoint := fmt.Sprintf("referees/%d", refereeID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	return media, nil
+	var referee Person
+	err = c.decodeResponse(endpoint, body, &referee)
+	return &referee, err
+}
+
+// PersonSearchOptions narrows down SearchPersons. Zero fields are ignored
+type PersonSearchOptions struct {
+	// Only persons of this type, e.g. "player" or "coach"
+	Type string
+	// Only persons currently in the squad of this team
+	TeamID int
+	// Maximum number of results, left to the API when zero
+	Limit int
 }
 
-func (c *VSportsClient_s) GetPersonById(PersonID int, useCache bool) (*Person, error) {
-	body, err := c.request(fmt.Sprintf("person/%d", PersonID), nil, useCache)
+// SearchPersons finds the persons whose name matches the given one
+func (c *VSportsClient_s) SearchPersons(ctx context.Context, name string, search PersonSearchOptions, opts ...RequestOption) ([]Person, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name to search for is empty")
+	}
+	params := map[string]string{"name": name}
+	if search.Type != "" {
+		params["type"] = search.Type
+	}
+	if search.TeamID > 0 {
+		params["team_id"] = strconv.Itoa(search.TeamID)
+	}
+	if search.Limit > 0 {
+		params["limit"] = strconv.Itoa(search.Limit)
+	}
+
+	body, err := c.request(ctx, "person/search", params, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var person Person
-	err = json.Unmarshal(body, &person)
-	return &person, err
+	var persons []Person
+	err = c.decodeList("person/search", body, &persons, opts)
+	return persons, err
+}
+
+// GetPersonCareer returns the clubs a person played for, one entry per team and season
+func (c *VSportsClient_s) GetPersonCareer(ctx context.Context, personID int, opts ...RequestOption) ([]CareerEntry, error) {
+	endpoint := fmt.Sprintf("person/%d/career", personID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var career []CareerEntry
+	err = c.decodeResponse(endpoint, body, &career)
+	return career, err
 }
 
-func (c *VSportsClient_s) GetSquad(teamID int, useCache bool) (*Squad, error) {
-	body, err := c.request(fmt.Sprintf("squads/%d", teamID), nil, useCache)
+func (c *VSportsClient_s) GetSquad(ctx context.Context, teamID int, opts ...RequestOption) (*Squad, error) {
+	endpoint := fmt.Sprintf("squads/%d", teamID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var squad Squad
-	err = json.Unmarshal(body, &squad)
+	err = c.decodeResponse(endpoint, body, &squad)
 	return &squad, err
 }
 
-func (c *VSportsClient_s) GetSquadDetailed(teamID int, useCache bool) (*Squad, error) {
-	body, err := c.request(fmt.Sprintf("squads/%d/detailed", teamID), nil, useCache)
+func (c *VSportsClient_s) GetSquadDetailed(ctx context.Context, teamID int, opts ...RequestOption) (*Squad, error) {
+	endpoint := fmt.Sprintf("squads/%d/detailed", teamID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var squad Squad
-	err = json.Unmarshal(body, &squad)
+	err = c.decodeResponse(endpoint, body, &squad)
 	return &squad, err
 }
 
-func (c *VSportsClient_s) GetSquadByTournament(teamID, tournamentID int, useCache bool) (*Squad, error) {
-	body, err := c.request(fmt.Sprintf("squads/%d/by/tournament/%d", teamID, tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetSquadByTournament(ctx context.Context, teamID, tournamentID int, opts ...RequestOption) (*Squad, error) {
+	endpoint := fmt.Sprintf("squads/%d/by/tournament/%d", teamID, tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var squad Squad
-	err = json.Unmarshal(body, &squad)
+	err = c.decodeResponse(endpoint, body, &squad)
 	return &squad, err
 }
 
-func (c *VSportsClient_s) GetSquadDetailedByTournament(teamID, tournamentID int, useCache bool) (*Squad, error) {
-	body, err := c.request(fmt.Sprintf("squads/%d/by/tournament/%d/detailed", teamID, tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetSquadDetailedByTournament(ctx context.Context, teamID, tournamentID int, opts ...RequestOption) (*Squad, error) {
+	endpoint := fmt.Sprintf("squads/%d/by/tournament/%d/detailed", teamID, tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var squad Squad
-	err = json.Unmarshal(body, &squad)
+	err = c.decodeResponse(endpoint, body, &squad)
 	return &squad, err
 }
 
-func (c *VSportsClient_s) GetStandingsByTournament(tournamentID int, useCache bool) (*Standings, error) {
-	body, err := c.request(fmt.Sprintf("standings/by/tournament/%d", tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetStandingsByTournament(ctx context.Context, tournamentID int, opts ...RequestOption) (*Standings, error) {
+	endpoint := fmt.Sprintf("standings/by/tournament/%d", tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var standings Standings
-	err = json.Unmarshal(body, &standings)
-	return &standings, err
+	if err := c.decodeResponse(endpoint, body, &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
 }
 
-func (c *VSportsClient_s) GetStandingsByTournamentLive(tournamentID int, useCache bool) (*Standings, error) {
-	body, err := c.request(fmt.Sprintf("standings/by/tournament/%d/live", tournamentID), nil, useCache)
+func (c *VSportsClient_s) GetStandingsByTournamentLive(ctx context.Context, tournamentID int, opts ...RequestOption) (*Standings, error) {
+	endpoint := fmt.Sprintf("standings/by/tournament/%d/live", tournamentID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var standings Standings
-	err = json.Unmarshal(body, &standings)
-	return &standings, err
+	if err := c.decodeResponse(endpoint, body, &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
+
+// GetGroupStandings returns the table of every group of the tournament, for
+// competitions with a group stage. It's empty for tournaments without groups
+func (c *VSportsClient_s) GetGroupStandings(ctx context.Context, tournamentID int, opts ...RequestOption) ([]GroupStandings, error) {
+	standings, err := c.GetStandingsByTournament(ctx, tournamentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []GroupStandings
+	for _, stage := range standings.Stage {
+		for _, group := range stage.Groups {
+			group.Stage = stage.Name
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
 }
 
-func (c *VSportsClient_s) GetVenue(venueID int, useCache bool) (*Venue, error) {
-	body, err := c.request(fmt.Sprintf("venues/%d", venueID), nil, useCache)
+func (c *VSportsClient_s) GetVenue(ctx context.Context, venueID int, opts ...RequestOption) (*Venue, error) {
+	endpoint := fmt.Sprintf("venues/%d", venueID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var venue Venue
-	err = json.Unmarshal(body, &venue)
+	err = c.decodeResponse(endpoint, body, &venue)
 	return &venue, err
 }
 
-func (c *VSportsClient_s) GetVenuesByTeam(teamID int, useCache bool) ([]Venue, error) {
-	body, err := c.request(fmt.Sprintf("venues/by/team/%d", teamID), nil, useCache)
+func (c *VSportsClient_s) GetVenuesByTeam(ctx context.Context, teamID int, opts ...RequestOption) ([]Venue, error) {
+	endpoint := fmt.Sprintf("venues/by/team/%d", teamID)
+	body, err := c.request(ctx, endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var venues []Venue
-	err = json.Unmarshal(body, &venues)
+	err = c.decodeResponse(endpoint, body, &venues)
 	return venues, err
 }
+
+// GetBroadcasts returns where an event can be watched or listened to
+// The TV channels of events without broadcast listings are returned as TV broadcasts
+func (c *VSportsClient_s) GetBroadcasts(ctx context.Context, eventID int, opts ...RequestOption) ([]Broadcast, error) {
+	event, err := c.GetEventDetailed(ctx, eventID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(event.Broadcasts) > 0 {
+		return event.Broadcasts, nil
+	}
+
+	var broadcasts []Broadcast
+	for _, channel := range event.TVChannel {
+		broadcasts = append(broadcasts, Broadcast{Broadcaster: channel.Name, Type: "tv", Channel: channel, Country: channel.Country})
+	}
+	return broadcasts, nil
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the client, used for cache and token expiry, call
+// budgets, failover cooldowns, usage windows and the scheduling of background work
+// Tests can use a ManualClock to control it
+type Clock interface {
+	Now() time.Time
+	// NewTicker is used for periodic work, like watching the config file
+	NewTicker(d time.Duration) Ticker
+	// After is used to wait, like between retries
+	After(d time.Duration) <-chan time.Time
+}
+
+// Ticker is the part of time.Ticker used by the client
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the Clock backed by the time package, used by default
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time                         { return time.Now() }
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (SystemClock) NewTicker(d time.Duration) Ticker       { return systemTicker{time.NewTicker(d)} }
+
+type systemTicker struct{ ticker *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.ticker.C }
+func (t systemTicker) Stop()               { t.ticker.Stop() }
+
+// ManualClock is a Clock that only moves when told to, for deterministic tests
+// Tickers and waits fire as Advance moves the time past them
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+// A pending After or Ticker of a ManualClock
+type manualWaiter struct {
+	at     time.Time
+	period time.Duration // zero for After
+	c      chan time.Time
+}
+
+// NewManualClock creates a clock stopped at the given time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (m *ManualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *ManualClock) After(d time.Duration) <-chan time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := &manualWaiter{at: m.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- m.now
+		return w.c
+	}
+	m.waiters = append(m.waiters, w)
+	return w.c
+}
+
+func (m *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := &manualWaiter{at: m.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	m.waiters = append(m.waiters, w)
+	return &manualTicker{clock: m, waiter: w}
+}
+
+// Set moves the clock to the given time, firing what's due on the way
+func (m *ManualClock) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for {
+		// Fire the waiters in order, so tickers see the time they were due at
+		sort.Slice(m.waiters, func(i, j int) bool { return m.waiters[i].at.Before(m.waiters[j].at) })
+		if len(m.waiters) == 0 || m.waiters[0].at.After(t) {
+			break
+		}
+		w := m.waiters[0]
+		m.now = w.at
+		// Like time.Ticker, ticks are dropped when the reader is behind
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			m.waiters = m.waiters[1:]
+		}
+	}
+	if t.After(m.now) {
+		m.now = t
+	}
+}
+
+// Advance moves the clock forward, firing what's due on the way
+func (m *ManualClock) Advance(d time.Duration) {
+	m.Set(m.Now().Add(d))
+}
+
+type manualTicker struct {
+	clock  *ManualClock
+	waiter *manualWaiter
+}
+
+func (t *manualTicker) C() <-chan time.Time { return t.waiter.c }
+
+func (t *manualTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, w := range t.clock.waiters {
+		if w == t.waiter {
+			t.clock.waiters = append(t.clock.waiters[:i], t.clock.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Validators of a response, ETag and Last-Modified, sent back with a conditional
+// request to revalidate it once expired
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+func validatorsOf(resp *http.Response) validators {
+	return validators{etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}
+}
+
+func (v validators) empty() bool {
+	return v.etag == "" && v.lastModified == ""
+}
+
+// Makes req conditional. The ETag wins, servers ignore If-Modified-Since along with If-None-Match
+func (v validators) apply(req *http.Request) {
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	} else if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+}
+
+// Cache entries hold them on their header line, after a tab, which can't be in either
+func (v validators) encode() string {
+	if v.empty() {
+		return ""
+	}
+	return "\t" + v.etag + "\t" + v.lastModified
+}
+
+func decodeValidators(s string) validators {
+	etag, lastModified, _ := strings.Cut(s, "\t")
+	return validators{etag: etag, lastModified: lastModified}
+}
+
+// A response of the API
+type fetchedResponse struct {
+	body       []byte
+	validators validators
+	// The API answered a conditional request with 304 Not Modified, so there's no body
+	// and the cached copy is still good
+	notModified bool
+}
+
+// Makes the request conditional on the copy the caller already has, e.g. the last poll
+// of WatchEvent, which is served when the API answers 304 Not Modified, and sets *v to
+// the validators of the response for the next request
+func withPrevious(previous cacheEntry, v *validators) RequestOption {
+	return func(o *requestOptions) {
+		if !previous.validators.empty() {
+			o.revalidate = &previous
+		}
+		o.validators = v
+	}
+}
+
+// Validators sent with the request, none when there's no cached copy to revalidate
+func (o requestOptions) conditional() validators {
+	if o.revalidate == nil {
+		return validators{}
+	}
+	return o.revalidate.validators
+}
@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// A client caching for a minute, keeping expired responses for an hour to revalidate them
+func newConditionalClient(t *testing.T, api *testAPI, clock client.Clock) (*client.VSportsClient_s, func() []client.AuditRecord) {
+	var mu sync.Mutex
+	var records []client.AuditRecord
+	c := newTestClient(t, client.ClientConfig{
+		Clock:                    clock,
+		ConditionalWindowSeconds: 3600,
+		AuditSink: client.AuditFunc(func(record client.AuditRecord) {
+			mu.Lock()
+			defer mu.Unlock()
+			records = append(records, record)
+		}),
+	}, api.URL)
+	return c, func() []client.AuditRecord {
+		mu.Lock()
+		defer mu.Unlock()
+		return records
+	}
+}
+
+func TestNotModifiedRenewsExpiredResponse(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": 1}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c, records := newConditionalClient(t, api, clock)
+	get := func() {
+		t.Helper()
+		body, err := c.GetRaw(context.Background(), "teams/1", nil, client.WithTTL(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != `{"id": 1}` {
+			t.Fatalf("got %s", body)
+		}
+	}
+
+	get()
+	clock.Advance(2 * time.Minute)
+	get()
+	if record := records()[1]; record.Cache != client.AuditCacheNotModified || record.Status != http.StatusNotModified {
+		t.Errorf("got %+v, want a 304 recorded as %s", record, client.AuditCacheNotModified)
+	}
+
+	// Renewed for another minute
+	clock.Advance(30 * time.Second)
+	get()
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	if record := records()[2]; record.Cache != client.AuditCacheHit {
+		t.Errorf("got %+v, want a cache hit", record)
+	}
+}
+
+func TestChangedResponseReplacesExpired(t *testing.T) {
+	lastModified := "Sat, 14 Mar 2026 20:00:00 GMT"
+	var api *testAPI
+	api = newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if api.calls.Load() == 1 {
+			w.Header().Set("Last-Modified", lastModified)
+			w.Write([]byte(`{"id": 1, "name": "before"}`))
+			return
+		}
+		if r.Header.Get("If-Modified-Since") != lastModified {
+			t.Errorf("got If-Modified-Since %q, want %q", r.Header.Get("If-Modified-Since"), lastModified)
+		}
+		w.Write([]byte(`{"id": 1, "name": "after"}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c, records := newConditionalClient(t, api, clock)
+
+	c.GetRaw(context.Background(), "teams/1", nil, client.WithTTL(time.Minute))
+	clock.Advance(2 * time.Minute)
+	body, err := c.GetRaw(context.Background(), "teams/1", nil, client.WithTTL(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"id": 1, "name": "after"}` {
+		t.Errorf("got %s, want the changed response", body)
+	}
+	if record := records()[1]; record.Cache != client.AuditCacheMiss || record.Status != http.StatusOK {
+		t.Errorf("got %+v, want a 200 recorded as a miss", record)
+	}
+}
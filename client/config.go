@@ -0,0 +1,286 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults applied to zero values in ClientConfig
+const (
+	DefaultTimeoutSeconds = 10
+	DefaultCacheDuration  = 300 // 5 minutes
+	DefaultRedisAddr      = "localhost:6379"
+	DefaultUserAgent      = "vsports-go"
+
+	DefaultPreviewCacheDuration    = 600    // 10 minutes
+	DefaultHistoricalCacheDuration = 604800 // 7 days
+)
+
+// ErrInvalidConfig is wrapped by every error returned from ClientConfig.Validate
+var ErrInvalidConfig = errors.New("invalid client config")
+
+// WithDefaults returns a copy of the config with the documented defaults applied to unset fields
+func (config ClientConfig) WithDefaults() ClientConfig {
+	if config.TimeoutSeconds == 0 {
+		config.TimeoutSeconds = DefaultTimeoutSeconds
+	}
+	if config.CacheDuration == 0 {
+		config.CacheDuration = DefaultCacheDuration
+	}
+	if config.HistoricalCacheDuration == 0 {
+		config.HistoricalCacheDuration = DefaultHistoricalCacheDuration
+	}
+	if config.PreviewCacheDuration == 0 {
+		config.PreviewCacheDuration = DefaultPreviewCacheDuration
+	}
+	if config.WatchIntervalSeconds == 0 {
+		config.WatchIntervalSeconds = int(DefaultWatchInterval / time.Second)
+	}
+	if config.WatchIdleIntervalSeconds == 0 {
+		config.WatchIdleIntervalSeconds = int(DefaultWatchIdleInterval / time.Second)
+	}
+	// Explicit zeros are kept, as they turn that part of the budget off
+	if config.RetryBudget.Ratio == nil {
+		ratio := DefaultRetryBudgetRatio
+		config.RetryBudget.Ratio = &ratio
+	}
+	if config.RetryBudget.MinRetries == nil {
+		minRetries := DefaultRetryBudgetMinRetries
+		config.RetryBudget.MinRetries = &minRetries
+	}
+	if config.RetryBudget.WindowSeconds == 0 {
+		config.RetryBudget.WindowSeconds = DefaultRetryBudgetWindowSeconds
+	}
+	if config.CircuitBreaker.FailureThreshold == 0 {
+		config.CircuitBreaker.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if config.CircuitBreaker.OpenSeconds == 0 {
+		config.CircuitBreaker.OpenSeconds = DefaultBreakerOpenSeconds
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryBackoff == nil {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+	if config.FailoverBackoff == nil {
+		config.FailoverBackoff = DefaultFailoverBackoff
+	}
+	if config.MaxMediaBytes == 0 {
+		config.MaxMediaBytes = DefaultMaxMediaBytes
+	}
+	if len(config.BaseURLs) == 0 {
+		config.BaseURLs = []string{DefaultBaseURL}
+	}
+	if config.RedisConfig.Addr == "" {
+		config.RedisConfig.Addr = DefaultRedisAddr
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+	return config
+}
+
+// Validate checks the config for values that would produce a broken client
+// All problems found are reported together
+func (config ClientConfig) Validate() error {
+	return config.validate(true)
+}
+
+// The API key isn't required in the base config of a ClientManager, since each tenant brings its own
+func (config ClientConfig) validate(requireKey bool) error {
+	var errs []error
+
+	if requireKey && config.APIKey == "" && len(config.APIKeys) == 0 && config.APIKeyFile == "" && config.Credentials == nil && config.OAuth2 == nil {
+		errs = append(errs, errors.New("one of apiKey, apiKeys, apiKeyFile or oauth2 is required"))
+	}
+	if config.OAuth2 != nil {
+		if config.OAuth2.TokenURL == "" {
+			errs = append(errs, errors.New("oauth2.tokenUrl is required"))
+		}
+		if config.OAuth2.ClientID == "" {
+			errs = append(errs, errors.New("oauth2.clientId is required"))
+		}
+	}
+	if slices.Contains(config.APIKeys, "") {
+		errs = append(errs, errors.New("apiKeys must not contain empty keys"))
+	}
+	switch config.CacheBackend {
+	case "", CacheBackendRedis, CacheBackendMemory, CacheBackendNone:
+	default:
+		errs = append(errs, fmt.Errorf("cacheBackend must be %q, %q or %q, got %q", CacheBackendRedis, CacheBackendMemory, CacheBackendNone, config.CacheBackend))
+	}
+	if config.CacheMaxEntries < 0 {
+		errs = append(errs, fmt.Errorf("cacheMaxEntries must not be negative, got %d", config.CacheMaxEntries))
+	}
+	switch config.KeyStrategy {
+	case "", KeyStrategyRoundRobin, KeyStrategyMostRemaining:
+	default:
+		errs = append(errs, fmt.Errorf("keyStrategy must be %q or %q, got %q", KeyStrategyRoundRobin, KeyStrategyMostRemaining, config.KeyStrategy))
+	}
+	if config.TimeoutSeconds < 0 {
+		errs = append(errs, fmt.Errorf("timeoutSeconds must not be negative, got %d", config.TimeoutSeconds))
+	}
+	if config.CacheDuration < 0 {
+		errs = append(errs, fmt.Errorf("cacheDuration must not be negative, got %d", config.CacheDuration))
+	}
+	if config.HistoricalCacheDuration < 0 {
+		errs = append(errs, fmt.Errorf("historicalCacheDuration must not be negative, got %d", config.HistoricalCacheDuration))
+	}
+	if config.PreviewCacheDuration < 0 {
+		errs = append(errs, fmt.Errorf("previewCacheDuration must not be negative, got %d", config.PreviewCacheDuration))
+	}
+	if config.WatchIntervalSeconds < 0 {
+		errs = append(errs, fmt.Errorf("watchIntervalSeconds must not be negative, got %d", config.WatchIntervalSeconds))
+	}
+	if config.WatchIdleIntervalSeconds < 0 {
+		errs = append(errs, fmt.Errorf("watchIdleIntervalSeconds must not be negative, got %d", config.WatchIdleIntervalSeconds))
+	}
+	if config.MaxMediaBytes < 0 {
+		errs = append(errs, fmt.Errorf("maxMediaBytes must not be negative, got %d", config.MaxMediaBytes))
+	}
+	if config.ProxyURL != "" {
+		if _, err := parseProxyURL(config.ProxyURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if config.UsageWindowMinutes < 0 {
+		errs = append(errs, fmt.Errorf("usageWindowMinutes must not be negative, got %d", config.UsageWindowMinutes))
+	}
+	for _, threshold := range config.QuotaWarningThresholds {
+		if threshold <= 0 || threshold > 1 {
+			errs = append(errs, fmt.Errorf("quotaWarningThresholds must be between 0 and 1, got %v", threshold))
+		}
+	}
+	for _, baseURL := range config.BaseURLs {
+		if u, err := url.Parse(baseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("baseUrls must be absolute http(s) URLs, got %q", baseURL))
+		}
+	}
+	if config.MaxCallsPerHour < 0 {
+		errs = append(errs, fmt.Errorf("maxCallsPerHour must not be negative, got %d", config.MaxCallsPerHour))
+	}
+	if config.MaxCallsPerDay < 0 {
+		errs = append(errs, fmt.Errorf("maxCallsPerDay must not be negative, got %d", config.MaxCallsPerDay))
+	}
+	if strings.ContainsAny(config.CacheNamespace, ":/#") {
+		errs = append(errs, fmt.Errorf("cacheNamespace must not contain ':', '/' or '#', got %q", config.CacheNamespace))
+	}
+	if _, err := parseLogLevel(config.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	if config.Degraded.StaleTTLSeconds < 0 {
+		errs = append(errs, fmt.Errorf("degraded.staleTtlSeconds must not be negative, got %d", config.Degraded.StaleTTLSeconds))
+	}
+	if ratio := config.RetryBudget.Ratio; ratio != nil && (*ratio < 0 || *ratio > 1) {
+		errs = append(errs, fmt.Errorf("retryBudget.ratio must be between 0 and 1, got %v", *ratio))
+	}
+	if minRetries := config.RetryBudget.MinRetries; minRetries != nil && *minRetries < 0 {
+		errs = append(errs, fmt.Errorf("retryBudget.minRetries must not be negative, got %d", *minRetries))
+	}
+	if config.RetryBudget.WindowSeconds < 0 {
+		errs = append(errs, fmt.Errorf("retryBudget.windowSeconds must not be negative, got %d", config.RetryBudget.WindowSeconds))
+	}
+	if config.StaleWhileRevalidateSeconds < 0 {
+		errs = append(errs, fmt.Errorf("staleWhileRevalidateSeconds must not be negative, got %d", config.StaleWhileRevalidateSeconds))
+	}
+	if config.ConditionalWindowSeconds < 0 {
+		errs = append(errs, fmt.Errorf("conditionalWindowSeconds must not be negative, got %d", config.ConditionalWindowSeconds))
+	}
+	if config.RateLimit.RequestsPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.requestsPerMinute must not be negative, got %d", config.RateLimit.RequestsPerMinute))
+	}
+	if config.RateLimit.Burst < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.burst must not be negative, got %d", config.RateLimit.Burst))
+	}
+	if config.RateLimit.MaxQueue < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.maxQueue must not be negative, got %d", config.RateLimit.MaxQueue))
+	}
+	switch config.RateLimit.Mode {
+	case "", RateLimitBlock, RateLimitFailFast, RateLimitQueue:
+	default:
+		errs = append(errs, fmt.Errorf("rateLimit.mode must be %q, %q or %q, got %q", RateLimitBlock, RateLimitFailFast, RateLimitQueue, config.RateLimit.Mode))
+	}
+	if config.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("maxAttempts must not be negative, got %d", config.MaxAttempts))
+	}
+	if config.CircuitBreaker.FailureThreshold < 0 {
+		errs = append(errs, fmt.Errorf("circuitBreaker.failureThreshold must not be negative, got %d", config.CircuitBreaker.FailureThreshold))
+	}
+	if config.CircuitBreaker.OpenSeconds < 0 {
+		errs = append(errs, fmt.Errorf("circuitBreaker.openSeconds must not be negative, got %d", config.CircuitBreaker.OpenSeconds))
+	}
+	if rate := config.SchemaValidation.SampleRate; rate < 0 || rate > 1 {
+		errs = append(errs, fmt.Errorf("schemaValidation.sampleRate must be between 0 and 1, got %v", rate))
+	}
+	if config.RedisConfig.DB < 0 {
+		errs = append(errs, fmt.Errorf("redisConfig.db must not be negative, got %d", config.RedisConfig.DB))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+// LoadConfig reads a client config from a YAML, TOML or JSON file, chosen by the file extension
+// The keys are the same in every format and match the JSON tags of ClientConfig, e.g.
+//
+//	apiKey: my_api_key
+//	timeoutSeconds: 10
+//	redisConfig:
+//	  addr: localhost:6379
+//
+// Unknown keys are rejected so typos don't go unnoticed. Defaults are applied and the
+// resulting config is validated before being returned
+func LoadConfig(path string) (ClientConfig, error) {
+	var config ClientConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	// YAML and TOML are decoded into a generic map and converted to JSON,
+	// so ClientConfig only needs one set of tags
+	raw := map[string]any{}
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".json":
+		// Decoded directly below
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return config, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+	if err != nil {
+		return config, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	if ext != ".json" {
+		if data, err = json.Marshal(raw); err != nil {
+			return config, fmt.Errorf("error converting config file %s: %w", path, err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return config, fmt.Errorf("error decoding config file %s: %w", path, err)
+	}
+
+	config = config.WithDefaults()
+	return config, config.Validate()
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Credentials are the secrets needed by the client
+type Credentials struct {
+	APIKey        string
+	RedisPassword string
+}
+
+// CredentialsProvider supplies the client credentials
+// It is consulted for every API request and every new Redis connection,
+// so rotated secrets are picked up without recreating the client
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentials is a CredentialsProvider that never changes
+type StaticCredentials Credentials
+
+func (s StaticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// CredentialsFunc adapts a plain function (e.g. a secret manager lookup) to the CredentialsProvider interface
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+func (f CredentialsFunc) Credentials(ctx context.Context) (Credentials, error) { return f(ctx) }
+
+// DefaultCredentialsCheckInterval is how often FileCredentials checks the files for changes
+const DefaultCredentialsCheckInterval = 10 * time.Second
+
+// FileCredentials reads the credentials from files, such as mounted Kubernetes or Docker secrets
+// The files are checked for changes at most once per CheckInterval and re-read when modified
+type FileCredentials struct {
+	APIKeyPath        string
+	RedisPasswordPath string
+	CheckInterval     time.Duration
+	// Source of time for CheckInterval, defaults to SystemClock. The client sets it to its
+	// own Clock for the files of its ClientConfig
+	Clock Clock
+
+	mu          sync.Mutex
+	current     Credentials
+	modTimes    [2]time.Time
+	lastChecked time.Time
+}
+
+// NewFileCredentials creates a FileCredentials reading the given files
+// Either path can be empty when that secret isn't needed
+func NewFileCredentials(apiKeyPath, redisPasswordPath string) *FileCredentials {
+	return &FileCredentials{
+		APIKeyPath:        apiKeyPath,
+		RedisPasswordPath: redisPasswordPath,
+		CheckInterval:     DefaultCredentialsCheckInterval,
+		Clock:             SystemClock{},
+	}
+}
+
+func (f *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var now time.Time
+	if f.Clock != nil {
+		now = f.Clock.Now()
+	} else {
+		now = time.Now()
+	}
+	if !f.lastChecked.IsZero() && now.Sub(f.lastChecked) < f.CheckInterval {
+		return f.current, nil
+	}
+
+	creds := f.current
+	for i, target := range []struct {
+		path  string
+		value *string
+	}{
+		{f.APIKeyPath, &creds.APIKey},
+		{f.RedisPasswordPath, &creds.RedisPassword},
+	} {
+		if target.path == "" {
+			continue
+		}
+		info, err := os.Stat(target.path)
+		if err != nil {
+			return f.current, fmt.Errorf("error checking credentials file: %w", err)
+		}
+		if info.ModTime().Equal(f.modTimes[i]) {
+			continue
+		}
+		data, err := os.ReadFile(target.path)
+		if err != nil {
+			return f.current, fmt.Errorf("error reading credentials file: %w", err)
+		}
+		*target.value = strings.TrimSpace(string(data))
+		f.modTimes[i] = info.ModTime()
+	}
+
+	f.current = creds
+	f.lastChecked = now
+	return creds, nil
+}
+
+// Adapts a CredentialsProvider to the KeyProvider interface
+type credentialsKeyProvider struct {
+	provider CredentialsProvider
+}
+
+func (p credentialsKeyProvider) APIKey(ctx context.Context) (string, error) {
+	creds, err := p.provider.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	if creds.APIKey == "" {
+		return "", errors.New("credentials provider returned an empty API key")
+	}
+	return creds.APIKey, nil
+}
+
+// Authenticates every new Redis connection with the current password
+// Connections already in the pool stay authenticated, as Redis only checks on AUTH
+func redisCredentialsHook(provider CredentialsProvider) func(ctx context.Context, cn *redis.Conn) error {
+	return func(ctx context.Context, cn *redis.Conn) error {
+		creds, err := provider.Credentials(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting Redis password: %w", err)
+		}
+		if creds.RedisPassword == "" {
+			return nil
+		}
+		return cn.Auth(ctx, creds.RedisPassword).Err()
+	}
+}
+
+// SetCredentialsProvider makes the client take the API key from the given provider
+// The Redis password can only be set through ClientConfig.Credentials, since it's
+// needed before the connection to Redis is established
+func (c *VSportsClient_s) SetCredentialsProvider(provider CredentialsProvider) {
+	if provider == nil {
+		return
+	}
+	c.keys.set(credentialsKeyProvider{provider})
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidDateRange is returned, before any request is made, for a date range with a
+// missing day or starting after it ends
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+// Date is a calendar day, as the events endpoints filter on. The API matches it against
+// the day of the events in UTC. The zero Date is no day at all
+type Date struct {
+	// Midnight UTC of the day
+	day time.Time
+}
+
+// NewDate returns the given day, normalized like time.Date, so April 31 is May 1
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{day: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the day of t in its location, e.g. the local day of time.Now()
+// Use DateOf(t.UTC()) for the day the API puts t on
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate parses a day as "2006-01-02"
+func ParseDate(s string) (Date, error) {
+	day, err := time.Parse(eventsDateFormat, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return Date{day: day}, nil
+}
+
+// String formats the day as the API expects it, "2006-01-02", and the zero Date as ""
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.day.Format(eventsDateFormat)
+}
+
+// Time returns midnight of the day, in UTC
+func (d Date) Time() time.Time {
+	return d.day
+}
+
+func (d Date) IsZero() bool {
+	return d.day.IsZero()
+}
+
+// AddDays returns the day n days later, or earlier when n is negative
+func (d Date) AddDays(n int) Date {
+	return Date{day: d.day.AddDate(0, 0, n)}
+}
+
+func (d Date) Before(other Date) bool {
+	return d.day.Before(other.day)
+}
+
+func (d Date) After(other Date) bool {
+	return d.day.After(other.day)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Checks a date range before it's sent to the API, which would answer an empty list
+func validDateRange(start, end Date) error {
+	switch {
+	case start.IsZero() || end.IsZero():
+		return fmt.Errorf("%w: the start and the end are both required", ErrInvalidDateRange)
+	case start.After(end):
+		return fmt.Errorf("%w: %s is after %s", ErrInvalidDateRange, start, end)
+	}
+	return nil
+}
+
+// Layouts of the date and time of events, tried in order
+var eventTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// Returns when an event starts, from its date_time with the offset given by the API, or
+// else from its date_utc and time_utc. It's zero when neither can be parsed
+func parseEventTime(dateTime, dateUTC, timeUTC string) time.Time {
+	if t, err := time.Parse(time.RFC3339, dateTime); err == nil {
+		return t
+	}
+	for _, layout := range eventTimeLayouts {
+		if t, err := time.Parse(layout, dateUTC+" "+timeUTC); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
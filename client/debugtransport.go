@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRedactedHeaders are the headers whose values DebugTransport never logs
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", DefaultSignatureHeader}
+
+// Query parameters whose names contain one of these are redacted from logged URLs
+var redactedQueryParts = []string{"key", "token", "secret", "signature", "password"}
+
+const redacted = "[REDACTED]"
+
+// Brackets would be escaped in URLs
+const redactedURLValue = "REDACTED"
+
+type debugContextKey struct{}
+
+// WithDebug marks the requests made with the context to be logged by DebugTransport
+func WithDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugContextKey{}, true)
+}
+
+// IsDebug tells if the context was marked with WithDebug
+func IsDebug(ctx context.Context) bool {
+	debug, _ := ctx.Value(debugContextKey{}).(bool)
+	return debug
+}
+
+// DebugTransport is an http.RoundTripper logging the requests made with a context marked
+// with WithDebug: their timing, broken down into DNS, connect, TLS and time to first byte,
+// and their headers, with credentials redacted. Other requests go through untouched, so
+// it can stay in place in production and be turned on for a single request
+// The client always wraps its transport with one, logging to the client's logger
+type DebugTransport struct {
+	// http.DefaultTransport when nil
+	Base   http.RoundTripper
+	Logger *slog.Logger
+	// Headers redacted on top of DefaultRedactedHeaders
+	RedactHeaders []string
+	// Log every request, marked or not
+	All bool
+}
+
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Logger == nil || (!t.All && !IsDebug(req.Context())) {
+		return base.RoundTrip(req)
+	}
+
+	var timing requestTiming
+	start := time.Now()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timing.trace()))
+	resp, err := base.RoundTrip(req)
+	total := time.Since(start)
+	phases := timing.snapshot()
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactURL(req.URL)),
+		slog.Duration("total", total),
+		slog.Bool("reusedConn", phases.reused),
+		slog.Any("requestHeaders", t.redactHeaders(req.Header)),
+	}
+	attrs = append(attrs, phases.attrs()...)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		t.Logger.LogAttrs(req.Context(), slog.LevelWarn, "vsports http request failed", attrs...)
+		return nil, err
+	}
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.String("proto", resp.Proto),
+		slog.Any("responseHeaders", t.redactHeaders(resp.Header)))
+	t.Logger.LogAttrs(req.Context(), slog.LevelInfo, "vsports http request", attrs...)
+	return resp, nil
+}
+
+// Returns a copy of the headers with the sensitive values replaced
+func (t *DebugTransport) redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if slices.ContainsFunc(DefaultRedactedHeaders, func(h string) bool { return strings.EqualFold(h, name) }) ||
+			slices.ContainsFunc(t.RedactHeaders, func(h string) bool { return strings.EqualFold(h, name) }) {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// Returns the URL with its credentials and sensitive query parameters redacted
+func redactURL(u *url.URL) string {
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(redactedURLValue)
+	}
+	query := clean.Query()
+	changed := false
+	for name := range query {
+		lower := strings.ToLower(name)
+		if slices.ContainsFunc(redactedQueryParts, func(part string) bool { return strings.Contains(lower, part) }) {
+			query.Set(name, redactedURLValue)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+// The phases of a request, measured with httptrace
+type requestPhases struct {
+	dnsStart, dnsDone          time.Time
+	connectStart, connectDone  time.Time
+	tlsStart, tlsDone          time.Time
+	gotConn, firstByte         time.Time
+	reused                     bool
+	remoteAddr, tlsVersion     string
+	dnsErr, connectErr, tlsErr error
+}
+
+// Measures the phases of a request. The hooks of httptrace may run concurrently, e.g.
+// the connections of a dual-stack dial, and even after RoundTrip returned
+type requestTiming struct {
+	mu     sync.Mutex
+	phases requestPhases
+}
+
+// Records a phase of the request
+func (r *requestTiming) update(f func(p *requestPhases)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(&r.phases)
+}
+
+// Returns the phases recorded so far
+func (r *requestTiming) snapshot() requestPhases {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.phases
+}
+
+func (r *requestTiming) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			r.update(func(p *requestPhases) { p.dnsStart = time.Now() })
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			r.update(func(p *requestPhases) {
+				p.dnsDone = time.Now()
+				p.dnsErr = info.Err
+			})
+		},
+		ConnectStart: func(network, addr string) {
+			r.update(func(p *requestPhases) {
+				// With several addresses, only the first attempt is timed
+				if p.connectStart.IsZero() {
+					p.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(network, addr string, err error) {
+			r.update(func(p *requestPhases) {
+				p.connectDone = time.Now()
+				p.connectErr = err
+			})
+		},
+		TLSHandshakeStart: func() {
+			r.update(func(p *requestPhases) { p.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			r.update(func(p *requestPhases) {
+				p.tlsDone = time.Now()
+				p.tlsErr = err
+				if err == nil {
+					p.tlsVersion = tls.VersionName(state.Version)
+				}
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			r.update(func(p *requestPhases) {
+				p.gotConn = time.Now()
+				p.reused = info.Reused
+				if info.Conn != nil {
+					p.remoteAddr = info.Conn.RemoteAddr().String()
+				}
+			})
+		},
+		GotFirstResponseByte: func() {
+			r.update(func(p *requestPhases) { p.firstByte = time.Now() })
+		},
+	}
+}
+
+func (r requestPhases) attrs() []slog.Attr {
+	var attrs []slog.Attr
+	phase := func(name string, start, end time.Time, err error) {
+		if start.IsZero() || end.IsZero() {
+			return
+		}
+		attrs = append(attrs, slog.Duration(name, end.Sub(start)))
+		if err != nil {
+			attrs = append(attrs, slog.String(name+"Error", err.Error()))
+		}
+	}
+	phase("dns", r.dnsStart, r.dnsDone, r.dnsErr)
+	phase("connect", r.connectStart, r.connectDone, r.connectErr)
+	phase("tls", r.tlsStart, r.tlsDone, r.tlsErr)
+	phase("timeToFirstByte", r.gotConn, r.firstByte, nil)
+	if r.remoteAddr != "" {
+		attrs = append(attrs, slog.String("remoteAddr", r.remoteAddr))
+	}
+	if r.tlsVersion != "" {
+		attrs = append(attrs, slog.String("tlsVersion", r.tlsVersion))
+	}
+	return attrs
+}
+
+// CloseIdleConnections closes the idle connections of the base transport
+func (t *DebugTransport) CloseIdleConnections() {
+	type closeIdler interface{ CloseIdleConnections() }
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if c, ok := base.(closeIdler); ok {
+		c.CloseIdleConnections()
+	}
+}
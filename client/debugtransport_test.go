@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// Run with -race: the hooks of httptrace write the timings from the transport's goroutines
+func TestDebugTransportLogsRedactedTimings(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	var mu sync.Mutex
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&lockedWriter{mu: &mu, w: &logs}, nil))
+	httpClient := &http.Client{Transport: &client.DebugTransport{Logger: logger, All: true}}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", api.URL+"/teams/1?api_key=secret", nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if lines := strings.Count(logs.String(), "\n"); lines != 10 {
+		t.Errorf("got %d log lines, want 10", lines)
+	}
+	if strings.Contains(logs.String(), "secret") {
+		t.Errorf("credentials logged: %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), `"timeToFirstByte"`) {
+		t.Errorf("no timings logged: %s", logs.String())
+	}
+}
+
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *bytes.Buffer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
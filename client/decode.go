@@ -0,0 +1,211 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrMalformedResponse is wrapped by the errors of responses that aren't valid JSON,
+// are truncated or too large, or don't hold the resource asked for
+var ErrMalformedResponse = errors.New("malformed API response")
+
+// ErrUnknownFields is wrapped by the DecodeError of a response holding fields the
+// models don't know about, with ClientConfig.StrictDecoding
+var ErrUnknownFields = errors.New("unknown fields in API response")
+
+// Responses above this size are rejected instead of being read into memory
+const maxResponseBytes = 32 << 20 // 32 MiB
+
+// DecodeError is returned when a response doesn't decode into its model, telling what
+// went wrong where, e.g. to alert on changes of the API. It matches ErrMalformedResponse,
+// or ErrUnknownFields when the response only has fields the model doesn't know about
+type DecodeError struct {
+	// Endpoint of the response, e.g. "events/12", empty outside of the client methods
+	Endpoint string
+	// Model decoded into, e.g. "Event" or "[]Team"
+	Model string
+	// Path of the field that failed, e.g. "team_A.id", and the JSON value found there
+	// and the Go type expected. Empty when the response as a whole is broken
+	// Within a Tournament, Event, Team, Squad, SquadMember or Standings, the path starts
+	// at the innermost of them, named by Struct, e.g. "id" in "Team"
+	Struct   string
+	Field    string
+	Found    string
+	Expected string
+	// Position in the response of the error, when known
+	Offset int64
+	// Paths of the fields the model doesn't know about, e.g. "stage[].zones[].color"
+	Unknown []string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+	if len(e.Unknown) > 0 {
+		b.WriteString(ErrUnknownFields.Error())
+	} else {
+		b.WriteString(ErrMalformedResponse.Error())
+	}
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " from %s", e.Endpoint)
+	}
+	if e.Model != "" {
+		fmt.Fprintf(&b, " (%s)", e.Model)
+	}
+	switch {
+	case len(e.Unknown) > 0:
+		fmt.Fprintf(&b, ": %s", strings.Join(e.Unknown, ", "))
+	case e.Field != "":
+		field := e.Field
+		if e.Struct != "" {
+			field = e.Struct + "." + field
+		}
+		fmt.Fprintf(&b, ": field %s: expected %s, got %s", field, e.Expected, e.Found)
+	case e.Err != nil:
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	return b.String()
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrMalformedResponse && len(e.Unknown) == 0
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Decodes an API response into v, a pointer to a model
+// Unlike json.Unmarshal, an empty body or a null where a single resource was expected
+// is an error, so a broken response never turns into a zero value. Errors are DecodeErrors
+func decode(body []byte, v any) error {
+	model := strings.ReplaceAll(reflect.TypeOf(v).Elem().String(), "client.", "")
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return &DecodeError{Model: model, Err: errors.New("empty body")}
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		decodeErr := &DecodeError{Model: model, Err: err}
+		var typeErr *json.UnmarshalTypeError
+		var syntaxErr *json.SyntaxError
+		switch {
+		case errors.As(err, &typeErr):
+			decodeErr.Struct, decodeErr.Field = typeErr.Struct, typeErr.Field
+			decodeErr.Found, decodeErr.Expected = typeErr.Value, typeErr.Type.String()
+			decodeErr.Offset = typeErr.Offset
+		case errors.As(err, &syntaxErr):
+			decodeErr.Offset = syntaxErr.Offset
+		}
+		return decodeErr
+	}
+	if bytes.Equal(body, []byte("null")) {
+		if target := reflect.ValueOf(v); target.Kind() == reflect.Pointer && target.Elem().Kind() == reflect.Struct {
+			return &DecodeError{Model: model, Err: fmt.Errorf("null instead of %s", model)}
+		}
+	}
+	return nil
+}
+
+// Decodes the response of an endpoint, see decode. With StrictDecoding, a response
+// with fields the model doesn't know about fails too
+func (c *VSportsClient_s) decodeResponse(endpoint string, body []byte, v any) error {
+	err := decode(body, v)
+	if err == nil && c.strictDecoding {
+		if unknown := unknownFields(body, v); len(unknown) > 0 {
+			model := strings.ReplaceAll(reflect.TypeOf(v).Elem().String(), "client.", "")
+			err = &DecodeError{Model: model, Unknown: unknown, Err: ErrUnknownFields}
+		}
+	}
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		decodeErr.Endpoint = endpoint
+		c.logger.Warn(decodeErr.Error())
+	}
+	return err
+}
+
+// Decodes a list, from a page envelope or not, filling the PageInfo of the options
+func (c *VSportsClient_s) decodeList(endpoint string, body []byte, v any, opts []RequestOption) error {
+	return c.decodeResponse(endpoint, unwrapList(body, opts), v)
+}
+
+// Returns the paths of the non-empty fields of body lost when decoding it into v,
+// found by encoding v back, sorted. Array elements share the path of their array
+func unknownFields(body []byte, v any) []string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var original any
+	if decoder.Decode(&original) != nil {
+		return nil
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if json.Unmarshal(encoded, &decoded) != nil {
+		return nil
+	}
+
+	found := map[string]bool{}
+	missingFields("", original, decoded, found)
+	paths := make([]string, 0, len(found))
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func missingFields(path string, want, got any, found map[string]bool) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, _ := got.(map[string]any)
+		for key, value := range w {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			if gv, ok := g[key]; ok {
+				missingFields(child, value, gv, found)
+			} else if !emptyJSON(value) {
+				found[child] = true
+			}
+		}
+	case []any:
+		g, _ := got.([]any)
+		for i, value := range w {
+			if i < len(g) {
+				missingFields(path+"[]", value, g[i], found)
+			}
+		}
+	}
+}
+
+// Tells if a JSON value is the zero value omitempty leaves out
+func emptyJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		for _, value := range v {
+			if !emptyJSON(value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
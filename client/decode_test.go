@@ -0,0 +1,34 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+	"github.com/sapo/vsports-go/golden"
+)
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range golden.Seeds(golden.Corpus) {
+		f.Add(seed)
+	}
+	// IDs sent as strings, which the models accept
+	f.Add([]byte(`{"id": "301", "team_A": {"id": "201"}, "tournament": {"id": "101"}}`))
+	f.Add([]byte(`[{"id": "101", "name": "Liga Exemplo"}]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, strict := range []bool{false, true} {
+			err := golden.Fuzz(data, func(data []byte, v any) error {
+				err := client.DecodeResponse(data, v, strict)
+				var decodeErr *client.DecodeError
+				if err != nil && !errors.As(err, &decodeErr) {
+					t.Errorf("decoding %q: %v isn't a *DecodeError", data, err)
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultStaleTTL is how long responses are kept around for serving stale
+const DefaultStaleTTL = 24 * time.Hour
+
+// DegradedPolicy configures how the client behaves when its dependencies fail
+//
+//   - Redis down: with AllowWithoutCache the client starts anyway and requests bypass the
+//     cache until Redis is back. Otherwise the constructor fails, and so do requests that
+//     can't store their response
+//   - API down: with ServeStale the last good response is served, for up to StaleTTLSeconds
+//   - Both down: requests fail with an error matching ErrUnavailable
+type DegradedPolicy struct {
+	AllowWithoutCache bool `json:"allowWithoutCache"`
+	ServeStale        bool `json:"serveStale"`
+	// Defaults to DefaultStaleTTL
+	StaleTTLSeconds int `json:"staleTtlSeconds"`
+}
+
+func (p DegradedPolicy) staleTTL() time.Duration {
+	if p.StaleTTLSeconds > 0 {
+		return time.Duration(p.StaleTTLSeconds) * time.Second
+	}
+	return DefaultStaleTTL
+}
+
+// ErrUnavailable is matched by errors returned when neither the API nor the cache could serve a request
+var ErrUnavailable = errors.New("vsports API and cache are both unavailable")
+
+// UnavailableError tells why a request couldn't be served by the API nor by the cache
+type UnavailableError struct {
+	APIErr   error
+	CacheErr error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%v: api: %v, cache: %v", ErrUnavailable, e.APIErr, e.CacheErr)
+}
+
+func (e *UnavailableError) Is(target error) bool { return target == ErrUnavailable }
+
+func (e *UnavailableError) Unwrap() []error { return []error{e.APIErr, e.CacheErr} }
+
+// Stale copies live next to the regular cache entries, in their own namespace
+func staleKey(cacheKey string) string {
+	return staleCacheKeyPrefix + strings.TrimPrefix(cacheKey, cacheKeyPrefix)
+}
+
+// Reads a cache entry
+// A missing entry is not an error: found is false and err is nil
+// err is only set when the cache itself failed
+func (c *VSportsClient_s) cacheGet(ctx context.Context, key string) (value []byte, found bool, err error) {
+	entry, found, err := c.cacheLookup(ctx, key)
+	return entry.body, found, err
+}
+
+// Same as cacheGet, along with when the entry was fetched and how long it's fresh for
+func (c *VSportsClient_s) cacheLookup(ctx context.Context, key string) (entry cacheEntry, found bool, err error) {
+	value, found, err := c.cache.Get(ctx, key)
+	if !found || err != nil {
+		return cacheEntry{}, found, err
+	}
+	return decodeCacheEntry(value), true, nil
+}
+
+// Stores the fresh response in the cache and, when serving stale is enabled, as the stale copy
+// A zero ttl means the configured cache duration
+// Failures only make the request fail when the client isn't allowed to run without cache
+func (c *VSportsClient_s) cacheStore(ctx context.Context, cacheKey string, body []byte, v validators, useCache bool, ttl time.Duration) error {
+	now := c.clock.Now()
+	if useCache {
+		if ttl == 0 {
+			ttl = c.cacheDuration()
+		}
+		// Expired entries are kept a while longer for stale-while-revalidate, and to be
+		// revalidated with a conditional request when they have validators
+		keep := c.staleWhileRevalidate
+		if !v.empty() {
+			keep = max(keep, c.conditionalWindow)
+		}
+		if err := c.cache.Set(ctx, cacheKey, encodeCacheEntry(body, now, ttl, v), ttl+keep); err != nil {
+			c.logger.Error(fmt.Sprintf("Error setting cache for %s: %v", cacheKey, err))
+			if !c.degraded.AllowWithoutCache {
+				return fmt.Errorf("error setting cache for %s: %w", cacheKey, err)
+			}
+			// The cache is down, don't bother with the stale copy either
+			return nil
+		}
+		c.logger.Debug(fmt.Sprintf("Cached response for %s", cacheKey))
+	}
+
+	if c.degraded.ServeStale {
+		if err := c.cache.Set(ctx, staleKey(cacheKey), encodeCacheEntry(body, now, c.degraded.staleTTL(), v), c.degraded.staleTTL()); err != nil {
+			c.logger.Error(fmt.Sprintf("Error setting stale copy for %s: %v", cacheKey, err))
+		}
+	}
+	return nil
+}
+
+// Falls back to the stale copy of a response after the API failed
+// cacheErr is the error of the cache lookup made before calling the API, if any
+func (c *VSportsClient_s) serveStale(ctx context.Context, cacheKey string, apiErr, cacheErr error) ([]byte, error) {
+	if cacheErr == nil && c.degraded.ServeStale {
+		stale, found, err := c.cacheGet(ctx, staleKey(cacheKey))
+		if found {
+			c.logger.Warn(fmt.Sprintf("Serving stale response for %s: %v", cacheKey, apiErr))
+			return stale, nil
+		}
+		cacheErr = err
+	}
+
+	if cacheErr != nil {
+		c.logger.Error(fmt.Sprintf("API and cache both unavailable for %s", cacheKey))
+		return nil, &UnavailableError{APIErr: apiErr, CacheErr: cacheErr}
+	}
+	return nil, apiErr
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeprecationNotice is what the API said about the deprecation of an endpoint, through
+// the Deprecation (RFC 9745), Sunset (RFC 8594), Warning and Link headers
+type DeprecationNotice struct {
+	// With the IDs replaced by ":id", e.g. "teams/:id"
+	Route string `json:"route"`
+	// When the endpoint was or will be deprecated, zero when the API didn't say
+	DeprecatedAt time.Time `json:"deprecatedAt,omitempty"`
+	// When the endpoint will stop answering, zero when the API didn't say
+	Sunset time.Time `json:"sunset,omitempty"`
+	// Text of the Warning header, e.g. `299 - "Use /v2/teams"`
+	Warning string `json:"warning,omitempty"`
+	// Documentation of the deprecation or successor of the endpoint, from the Link header
+	Link      string    `json:"link,omitempty"`
+	FirstSeen time.Time `json:"firstSeen"`
+}
+
+func (n DeprecationNotice) String() string {
+	parts := []string{fmt.Sprintf("endpoint %s is deprecated", n.Route)}
+	if !n.DeprecatedAt.IsZero() {
+		parts = append(parts, "since "+n.DeprecatedAt.UTC().Format(time.DateOnly))
+	}
+	if !n.Sunset.IsZero() {
+		parts = append(parts, "sunset on "+n.Sunset.UTC().Format(time.DateOnly))
+	}
+	if n.Warning != "" {
+		parts = append(parts, "warning: "+n.Warning)
+	}
+	if n.Link != "" {
+		parts = append(parts, "see "+n.Link)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Warning code of persistent miscellaneous warnings, which APIs use for deprecations
+const warnMiscPersistent = "299"
+
+// Reads the deprecation headers of a response
+func parseDeprecation(header http.Header, route string, now time.Time) (DeprecationNotice, bool) {
+	notice := DeprecationNotice{Route: route, FirstSeen: now}
+	found := false
+
+	if value := strings.TrimSpace(header.Get("Deprecation")); value != "" {
+		found = true
+		if seconds, ok := strings.CutPrefix(value, "@"); ok {
+			if unix, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+				notice.DeprecatedAt = time.Unix(unix, 0)
+			}
+		} else if at, err := http.ParseTime(value); err == nil {
+			notice.DeprecatedAt = at
+		}
+	}
+	if value := header.Get("Sunset"); value != "" {
+		if at, err := http.ParseTime(value); err == nil {
+			notice.Sunset = at
+			found = true
+		}
+	}
+	for _, warning := range header.Values("Warning") {
+		if strings.HasPrefix(strings.TrimSpace(warning), warnMiscPersistent) {
+			notice.Warning = strings.TrimSpace(warning)
+			found = true
+		}
+	}
+	if found {
+		notice.Link = deprecationLink(header.Values("Link"))
+	}
+	return notice, found
+}
+
+// Returns the target of the deprecation, successor-version or sunset link, in that order
+func deprecationLink(values []string) string {
+	links := map[string]string{}
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			target = strings.Trim(strings.TrimSpace(target), "<>")
+			for _, param := range strings.Split(params, ";") {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(name, "rel") {
+					links[strings.ToLower(strings.Trim(rel, `"`))] = target
+				}
+			}
+		}
+	}
+	for _, rel := range []string{"deprecation", "successor-version", "sunset"} {
+		if target, ok := links[rel]; ok {
+			return target
+		}
+	}
+	return ""
+}
+
+// The deprecated endpoints seen so far
+type deprecationTracker struct {
+	mu            sync.Mutex
+	notices       map[string]DeprecationNotice
+	onDeprecation func(DeprecationNotice)
+}
+
+func newDeprecationTracker() *deprecationTracker {
+	return &deprecationTracker{notices: map[string]DeprecationNotice{}}
+}
+
+// Looks for deprecation headers in a response, reporting each endpoint the first time
+func (c *VSportsClient_s) checkDeprecation(ctx context.Context, endpoint string, resp *http.Response) {
+	route := normalizeEndpoint(endpoint)
+	notice, found := parseDeprecation(resp.Header, route, c.clock.Now())
+	if !found {
+		return
+	}
+
+	c.deprecations.mu.Lock()
+	_, seen := c.deprecations.notices[route]
+	if seen {
+		// Keep the latest dates, but don't report the endpoint again
+		notice.FirstSeen = c.deprecations.notices[route].FirstSeen
+	}
+	c.deprecations.notices[route] = notice
+	onDeprecation := c.deprecations.onDeprecation
+	c.deprecations.mu.Unlock()
+	if seen {
+		return
+	}
+
+	c.logger.Warn(fmt.Sprintf("Deprecated API: %s", notice))
+	c.observe(func(o Observer) { o.OnDeprecation(ctx, notice) })
+	if onDeprecation != nil {
+		c.safeCall("deprecation", func() error {
+			onDeprecation(notice)
+			return nil
+		})
+	}
+}
+
+// Deprecations returns the deprecated endpoints the client used so far, by route
+func (c *VSportsClient_s) Deprecations() []DeprecationNotice {
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+
+	notices := make([]DeprecationNotice, 0, len(c.deprecations.notices))
+	for _, notice := range c.deprecations.notices {
+		notices = append(notices, notice)
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].Route < notices[j].Route })
+	return notices
+}
+
+// OnDeprecation registers a function called the first time the API reports an endpoint
+// used by the client as deprecated, e.g. to open a ticket
+func (c *VSportsClient_s) OnDeprecation(fn func(DeprecationNotice)) {
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+	c.deprecations.onDeprecation = fn
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Returns a copy of the client sharing everything with it
+// Derived clients are cheap copies of a client with a setting changed. They share the
+// transport, the Redis connection, the health of the base URLs, the call and retry
+// budgets, the circuit breakers, the rate limit and usage tracking, the enrichers and
+// the observers of the client, so nothing is opened or pinged. Settings changed with
+// UpdateConfig apply to them too, except the cache duration of a client derived with
+// WithCacheTTL and the key of one derived with WithAPIKey
+func (c *VSportsClient_s) derive() *VSportsClient_s {
+	derived := *c
+	// Adding an enricher or observer to one must not show up in the other
+	derived.enrichers = slices.Clip(c.enrichers)
+	derived.observers = slices.Clip(c.observers)
+	return &derived
+}
+
+// WithLogger returns a derived client logging to logger, at the level of the client
+// Derived clients share the connections, budgets and breakers of the client, e.g. to
+// give each request its own logger without building a new client
+func (c *VSportsClient_s) WithLogger(logger *slog.Logger) *VSportsClient_s {
+	if logger == nil {
+		logger = slog.New(&noopLogger{})
+	}
+	derived := c.derive()
+	derived.logger = slog.New(&levelHandler{level: c.logLevel, handler: logger.Handler()})
+	return derived
+}
+
+// WithLocale returns a derived client asking the API for responses in another language,
+// e.g. "en". Responses are cached apart from those in other languages
+func (c *VSportsClient_s) WithLocale(locale string) *VSportsClient_s {
+	derived := c.derive()
+	derived.locale = locale
+	return derived
+}
+
+// WithCacheTTL returns a derived client caching responses for ttl instead of the
+// CacheDuration of the client. Previews and past seasons keep their own durations
+// A ttl that isn't positive leaves the cache duration as it is
+func (c *VSportsClient_s) WithCacheTTL(ttl time.Duration) *VSportsClient_s {
+	derived := c.derive()
+	if ttl > 0 {
+		derived.cacheTTL = ttl
+	}
+	return derived
+}
+
+// WithAPIKey returns a derived client making its requests with another key, e.g. the
+// one of a tenant. Its usage still counts towards the budgets shared with the client
+func (c *VSportsClient_s) WithAPIKey(apiKey string) *VSportsClient_s {
+	derived := c.derive()
+	derived.keys = newKeyHolder(StaticKey(apiKey))
+	return derived
+}
+
+// Returns how long responses are cached: the duration the client was derived with, or
+// else the CacheDuration of the config
+func (c *VSportsClient_s) cacheDuration() time.Duration {
+	if c.cacheTTL > 0 {
+		return c.cacheTTL
+	}
+	_, ttl, _ := c.settings.get()
+	return ttl
+}
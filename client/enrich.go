@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Enricher adds data from other sources to the events returned by the client,
+// such as the weather forecast at the venue (see the weather package)
+// Enrichers store their data in Event.Extensions, under a key of their own
+type Enricher interface {
+	// Name is the key of the enricher in Event.Extensions, and identifies it in logs
+	Name() string
+	Enrich(ctx context.Context, event *Event) error
+}
+
+// AddEnricher registers an enricher, run on every event returned by the event methods
+// Enrichers run in the order they were added. A failing enricher is logged and skipped,
+// the event is still returned. It must be called before the client is used concurrently
+func (c *VSportsClient_s) AddEnricher(enricher Enricher) {
+	c.enrichers = append(c.enrichers, enricher)
+}
+
+// Runs the enrichers on the events, within the request timeout
+func (c *VSportsClient_s) enrich(ctx context.Context, events []Event) {
+	if len(c.enrichers) == 0 || len(events) == 0 {
+		return
+	}
+	timeout, _, _ := c.settings.get()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for i := range events {
+		for _, enricher := range c.enrichers {
+			err := c.safeCall("enricher "+enricher.Name(), func() error {
+				return enricher.Enrich(ctx, &events[i])
+			})
+			if err != nil {
+				c.logger.Warn(fmt.Sprintf("Enricher %s failed on event %d: %v", enricher.Name(), events[i].ID, err))
+			}
+		}
+	}
+}
+
+// SetExtension stores data added to the event by an enricher
+func (e *Event) SetExtension(name string, value any) {
+	if e.Extensions == nil {
+		e.Extensions = map[string]any{}
+	}
+	e.Extensions[name] = value
+}
+
+// Kickoff returns the start time of the event, in UTC
+// It's false when the API didn't give a date and time that could be parsed
+func (e Event) Kickoff() (time.Time, bool) {
+	return e.DateTime.UTC(), !e.DateTime.IsZero()
+}
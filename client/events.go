@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strconv"
+)
+
+// DefaultEventsPageSize is the number of events per page fetched by an EventPager
+const DefaultEventsPageSize = 100
+
+// Query parameters of EventsQuery
+const (
+	paramTournament = "tournament_id"
+	paramTeam       = "team_id"
+)
+
+// EventsQuery selects the events walked by Events. Zero fields are ignored
+type EventsQuery struct {
+	// Both required, see GetEventsByDate
+	StartDate Date
+	EndDate   Date
+	// Only the events of this tournament, or played by this team
+	TournamentID int
+	TeamID       int
+	// Only the events with one of these statuses, e.g. EventStatusFinished
+	Statuses []string
+	// Fetch the detailed events, as GetEventsDetailedByDate does
+	Detailed bool
+	// Events per page, DefaultEventsPageSize when zero
+	PageSize int
+}
+
+// EventPager walks the pages of an EventsQuery, one event at a time, fetching each page
+// when it gets to it. Use it like a bufio.Scanner:
+//
+//	pager := c.Events(ctx, client.EventsQuery{
+//		StartDate:    client.NewDate(2025, time.August, 1),
+//		EndDate:      client.NewDate(2026, time.May, 31),
+//		TournamentID: 12,
+//	})
+//	for pager.Next() {
+//		event := pager.Event()
+//	}
+//	if err := pager.Err(); err != nil {
+//		// the pages after the one that failed weren't fetched
+//	}
+type EventPager struct {
+	client *VSportsClient_s
+	ctx    context.Context
+	query  EventsQuery
+	opts   []RequestOption
+
+	events []Event
+	index  int
+	page   int
+	info   PageInfo
+	// IDs of the events of the last page, to tell a page sent again
+	lastIDs []int
+	done    bool
+	err     error
+}
+
+// Events returns a pager over the events selected by the query
+// No request is made until Next is called. The options apply to every page, e.g. WithSport
+func (c *VSportsClient_s) Events(ctx context.Context, query EventsQuery, opts ...RequestOption) *EventPager {
+	if query.PageSize <= 0 {
+		query.PageSize = DefaultEventsPageSize
+	}
+	return &EventPager{client: c, ctx: ctx, query: query, opts: opts, index: -1}
+}
+
+// Next moves to the next event, fetching the next page when needed
+// It's false once all the events were walked or a page failed, see Err
+func (p *EventPager) Next() bool {
+	p.index++
+	for p.index >= len(p.events) {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.fetch()
+	}
+	return true
+}
+
+// Event returns the current event, after a call to Next returning true
+func (p *EventPager) Event() Event {
+	return p.events[p.index]
+}
+
+// Err returns the error of the page that failed, if any
+func (p *EventPager) Err() error {
+	return p.err
+}
+
+// Page returns the pagination of the page of the current event
+func (p *EventPager) Page() PageInfo {
+	return p.info
+}
+
+func (p *EventPager) fetch() {
+	p.page++
+	// The pagination of the pager wins over any in the options
+	opts := append(append([]RequestOption{}, p.opts...),
+		withParam(paramTournament, p.query.TournamentID),
+		withParam(paramTeam, p.query.TeamID),
+		WithStatus(p.query.Statuses...),
+		WithPage(p.page, p.query.PageSize),
+		WithPageInfo(&p.info),
+	)
+
+	get := p.client.GetEventsByDate
+	if p.query.Detailed {
+		get = p.client.GetEventsDetailedByDate
+	}
+	events, err := get(p.ctx, p.query.StartDate, p.query.EndDate, opts...)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	// A server ignoring the pagination sends the same page over and over
+	ids := make([]int, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	if p.page > 1 && slices.Equal(ids, p.lastIDs) {
+		p.events, p.done = nil, true
+		return
+	}
+	p.events, p.index, p.lastIDs = events, 0, ids
+
+	// Without a total, a short page is the last one
+	switch {
+	case len(events) == 0:
+		p.done = true
+	case p.info.Total >= 0:
+		p.done = !p.info.HasNext()
+	default:
+		p.done = len(events) < p.query.PageSize
+	}
+}
+
+// Sets a numeric query parameter, unless it's zero
+func withParam(name string, value int) RequestOption {
+	return func(o *requestOptions) {
+		if value != 0 {
+			o.params[name] = strconv.Itoa(value)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func walkEvents(t *testing.T, handler http.HandlerFunc) ([]int, *testAPI) {
+	t.Helper()
+	api := newTestAPI(t, handler)
+	c := newTestClient(t, client.ClientConfig{}, api.URL)
+	pager := c.Events(context.Background(), client.EventsQuery{
+		StartDate: client.NewDate(2026, time.March, 1),
+		EndDate:   client.NewDate(2026, time.March, 31),
+		PageSize:  2,
+	})
+	var ids []int
+	for pager.Next() {
+		ids = append(ids, pager.Event().ID)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ids, api
+}
+
+func TestEventPagerWalksPages(t *testing.T) {
+	ids, _ := walkEvents(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set(client.TotalCountHeader, "5")
+		switch page {
+		case 1, 2:
+			fmt.Fprintf(w, `[{"id": %d}, {"id": %d}]`, 2*page-1, 2*page)
+		default:
+			w.Write([]byte(`[{"id": 5}]`))
+		}
+	})
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("got events %v, want 1 to 5", ids)
+	}
+}
+
+func TestEventPagerStopsOnRepeatedPage(t *testing.T) {
+	// Pagination ignored, always a full page
+	ids, api := walkEvents(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+	})
+	if fmt.Sprint(ids) != "[1 2]" {
+		t.Errorf("got events %v, want 1 and 2 once", ids)
+	}
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+}
+
+func TestEventPagerStopsOnEmptyPage(t *testing.T) {
+	// A total the pages don't live up to
+	ids, api := walkEvents(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(client.TotalCountHeader, "1000")
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	if fmt.Sprint(ids) != "[1 2]" {
+		t.Errorf("got events %v, want 1 and 2", ids)
+	}
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+}
@@ -0,0 +1,10 @@
+package client
+
+import "log/slog"
+
+// DecodeResponse decodes body into v the way the client decodes responses, for the tests
+// of the client_test package, which can import golden
+func DecodeResponse(body []byte, v any, strict bool) error {
+	c := &VSportsClient_s{logger: slog.New(&noopLogger{}), strictDecoding: strict}
+	return c.decodeResponse("", body, v)
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the address of the VSports API
+const DefaultBaseURL = "https://extended.vsports.pt/api"
+
+// Consecutive failures after which a base URL is taken out of rotation
+// How long it stays out before being tried again is up to ClientConfig.FailoverBackoff
+const failoverThreshold = 3
+
+// EndpointState is the health of one of the configured base URLs
+type EndpointState struct {
+	URL                 string    `json:"url"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	DownUntil           time.Time `json:"downUntil,omitempty"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastSuccess         time.Time `json:"lastSuccess,omitempty"`
+
+	// Times in a row the base URL was taken out of rotation, and for how long the last time
+	cooldowns    int
+	lastCooldown time.Duration
+}
+
+// The base URLs of the API, in order of preference, with their health
+// Requests go to the first healthy base URL. One that keeps failing is skipped for a
+// cooldown period, after which it's tried again, so the client fails back to the
+// primary automatically once it recovers
+type endpointPool struct {
+	backoff Backoff
+
+	mu     sync.Mutex
+	states []EndpointState
+}
+
+func newEndpointPool(urls []string, backoff Backoff) *endpointPool {
+	if backoff == nil {
+		backoff = DefaultFailoverBackoff
+	}
+	p := &endpointPool{backoff: backoff}
+	for _, u := range urls {
+		p.states = append(p.states, EndpointState{URL: strings.TrimSuffix(u, "/"), Healthy: true})
+	}
+	return p
+}
+
+// Returns the base URLs to try for a request, healthy ones first in order of preference
+// Base URLs out of rotation are still returned last, so a request is never refused
+// just because every base URL failed recently
+func (p *endpointPool) candidates(now time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var healthy, down []string
+	for i := range p.states {
+		state := &p.states[i]
+		if !state.Healthy && !now.Before(state.DownUntil) {
+			// Cooldown is over, give it another chance
+			state.Healthy = true
+			state.DownUntil = time.Time{}
+		}
+		if state.Healthy {
+			healthy = append(healthy, state.URL)
+		} else {
+			down = append(down, state.URL)
+		}
+	}
+	return append(healthy, down...)
+}
+
+func (p *endpointPool) success(url string, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.states {
+		if p.states[i].URL == url {
+			p.states[i].Healthy = true
+			p.states[i].ConsecutiveFailures = 0
+			p.states[i].DownUntil = time.Time{}
+			p.states[i].LastSuccess = now
+			p.states[i].cooldowns = 0
+			p.states[i].lastCooldown = 0
+			return
+		}
+	}
+}
+
+// Records a failure, returning true and the cooldown if it took the base URL out of rotation
+func (p *endpointPool) failure(url string, err error, now time.Time) (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.states {
+		state := &p.states[i]
+		if state.URL != url {
+			continue
+		}
+		state.ConsecutiveFailures++
+		state.LastError = err.Error()
+		if state.Healthy && state.ConsecutiveFailures >= failoverThreshold {
+			state.Healthy = false
+			return p.cooldownLocked(state, now), true
+		}
+		if !state.Healthy {
+			// Failed again right after its cooldown
+			p.cooldownLocked(state, now)
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+// Takes the base URL out of rotation for the next delay of the backoff
+func (p *endpointPool) cooldownLocked(state *EndpointState, now time.Time) time.Duration {
+	state.cooldowns++
+	state.lastCooldown = p.backoff.Delay(state.cooldowns, state.lastCooldown)
+	state.DownUntil = now.Add(state.lastCooldown)
+	return state.lastCooldown
+}
+
+func (p *endpointPool) snapshot() []EndpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]EndpointState(nil), p.states...)
+}
+
+// EndpointStates returns the health of each configured base URL, in order of preference
+func (c *VSportsClient_s) EndpointStates() []EndpointState {
+	return c.endpoints.snapshot()
+}
@@ -0,0 +1,56 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestFailoverCooldown(t *testing.T) {
+	primary := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	secondary := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	minRetries := 10
+	c := newTestClient(t, client.ClientConfig{
+		Clock:           clock,
+		MaxAttempts:     1,
+		FailoverBackoff: client.ConstantBackoff(time.Minute),
+		RetryBudget:     client.RetryBudget{MinRetries: &minRetries},
+	}, primary.URL, secondary.URL)
+	get := func() {
+		t.Helper()
+		if _, err := c.GetRaw(context.Background(), "teams/1", nil, client.WithNoCache()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for range 3 {
+		get()
+	}
+	if state := c.EndpointStates()[0]; state.Healthy || !state.DownUntil.Equal(clock.Now().Add(time.Minute)) {
+		t.Fatalf("primary is %+v, want it out of rotation for a minute", state)
+	}
+
+	// Out of rotation, the primary isn't tried first
+	get()
+	if calls := primary.calls.Load(); calls != 3 {
+		t.Errorf("got %d calls to the primary, want 3", calls)
+	}
+
+	// Once the cooldown is over, it is
+	clock.Advance(time.Minute)
+	get()
+	if calls := primary.calls.Load(); calls != 4 {
+		t.Errorf("got %d calls to the primary after its cooldown, want 4", calls)
+	}
+	if calls := secondary.calls.Load(); calls != 5 {
+		t.Errorf("got %d calls to the secondary, want 5", calls)
+	}
+}
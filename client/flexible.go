@@ -0,0 +1,163 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// An integer the API may send as a number or as a string, e.g. the IDs, 12 or "12"
+// null and "" are zero
+type flexibleInt int
+
+func (i *flexibleInt) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		if unquoted == "" {
+			*i = 0
+			return nil
+		}
+		text = unquoted
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: jsonKind(data) + " " + string(data), Type: reflect.TypeOf(0)}
+	}
+	*i = flexibleInt(n)
+	return nil
+}
+
+// Names the kind of a JSON value for the errors
+func jsonKind(data []byte) string {
+	switch data[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	}
+	return "number"
+}
+
+// Completes the errors of the models below, as encoding/json doesn't tell the field of
+// the errors of UnmarshalJSON methods: only the flexibleInt ones have no field, and the
+// fields are relative to the innermost model, e.g. "id" of a Team within an Event
+// Other errors without a field are about the model as a whole, e.g. an array sent
+// instead of an object, and are reported against the model rather than its copy
+func modelError(model any, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		modelType := reflect.TypeOf(model).Elem()
+		switch {
+		case typeErr.Field != "":
+		case typeErr.Type.Kind() == reflect.Int:
+			typeErr.Field = "id"
+		default:
+			typeErr.Type = modelType
+		}
+		if typeErr.Struct == "" && typeErr.Field != "" {
+			typeErr.Struct = modelType.Name()
+		}
+	}
+	return err
+}
+
+// The models below decode like json.Unmarshal would, except for their ID, which is
+// decoded as a flexibleInt. Each decodes into a copy of its type without the methods,
+// so it doesn't recurse, along with the ID, which takes precedence as it's less nested
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var raw struct {
+		event
+		ID       flexibleInt `json:"id"`
+		DateTime string      `json:"date_time"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(e, err)
+	}
+	*e = Event(raw.event)
+	e.ID = int(raw.ID)
+	// With the offset given by the API, see parseEventTime
+	e.DateTime = parseEventTime(raw.DateTime, e.DateUTC, e.TimeUTC)
+	return nil
+}
+
+func (t *Tournament) UnmarshalJSON(data []byte) error {
+	type tournament Tournament
+	var raw struct {
+		tournament
+		ID flexibleInt `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(t, err)
+	}
+	*t = Tournament(raw.tournament)
+	t.ID = int(raw.ID)
+	return nil
+}
+
+func (t *Team) UnmarshalJSON(data []byte) error {
+	type team Team
+	var raw struct {
+		team
+		ID flexibleInt `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(t, err)
+	}
+	*t = Team(raw.team)
+	t.ID = int(raw.ID)
+	return nil
+}
+
+func (s *Squad) UnmarshalJSON(data []byte) error {
+	type squad Squad
+	var raw struct {
+		squad
+		ID flexibleInt `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(s, err)
+	}
+	*s = Squad(raw.squad)
+	s.ID = int(raw.ID)
+	return nil
+}
+
+func (m *SquadMember) UnmarshalJSON(data []byte) error {
+	type squadMember SquadMember
+	var raw struct {
+		squadMember
+		ID flexibleInt `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(m, err)
+	}
+	*m = SquadMember(raw.squadMember)
+	m.ID = int(raw.ID)
+	return nil
+}
+
+func (s *Standings) UnmarshalJSON(data []byte) error {
+	type standings Standings
+	var raw struct {
+		standings
+		TournamentID flexibleInt `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError(s, err)
+	}
+	*s = Standings(raw.standings)
+	s.TournamentID = int(raw.TournamentID)
+	// Standings read back from a cache or a snapshot get their zones too
+	s.annotateZones()
+	return nil
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Position is the role of a player on the field
+type Position string
+
+const (
+	PositionUnknown    Position = ""
+	PositionGoalkeeper Position = "goalkeeper"
+	PositionDefender   Position = "defender"
+	PositionMidfielder Position = "midfielder"
+	PositionForward    Position = "forward"
+)
+
+// ParsePosition reads the positions found in the API payloads, in English and
+// Portuguese, long or abbreviated, e.g. "Goalkeeper", "GK", "G" or "Guarda-redes"
+func ParsePosition(s string) Position {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "goalkeeper", "gk", "g", "guarda-redes", "gr":
+		return PositionGoalkeeper
+	case "defender", "df", "d", "defesa":
+		return PositionDefender
+	case "midfielder", "mf", "m", "médio", "medio":
+		return PositionMidfielder
+	case "forward", "attacker", "striker", "fw", "f", "a", "avançado", "avancado":
+		return PositionForward
+	}
+	return PositionUnknown
+}
+
+// PositionType returns the position of the squad member as a Position
+func (m SquadMember) PositionType() Position {
+	return ParsePosition(m.Position)
+}
+
+// PitchPosition returns where the player stands on the pitch, normalized between 0 and 1
+// x goes from the player's own goal line to the opponent's, y from the left touchline to
+// the right one, seen from the player's own goal. It's false when the API didn't give it
+func (m SquadMember) PitchPosition() (x, y float64, ok bool) {
+	if m.Coordinates == nil {
+		return 0, 0, false
+	}
+	return clamp01(m.Coordinates.X / 100), clamp01(m.Coordinates.Y / 100), true
+}
+
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
+
+// FormationSlot is a place of a formation, filled by a player with Formation.Assign
+type FormationSlot struct {
+	// Line 0 is the goalkeeper, then the lines from the defence to the attack
+	Line int `json:"line"`
+	// Place in the line, from the left of the team
+	Index    int          `json:"index"`
+	Position Position     `json:"position"`
+	Player   *SquadMember `json:"player,omitempty"`
+	// Place on the pitch, normalized like SquadMember.PitchPosition
+	// Taken from the player when the API gives it, otherwise spread evenly by line
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Formation is a parsed formation like "4-3-3"
+type Formation struct {
+	Name string `json:"name"`
+	// Players in each outfield line, from the defence to the attack
+	Lines []int           `json:"lines"`
+	Slots []FormationSlot `json:"slots"`
+}
+
+// ParseFormation parses a formation string such as "4-3-3" or "4-2-3-1"
+// The outfield lines must add up to 10 players
+func ParseFormation(s string) (*Formation, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid formation %q", s)
+	}
+
+	formation := &Formation{Name: strings.TrimSpace(s)}
+	total := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid formation %q", s)
+		}
+		formation.Lines = append(formation.Lines, n)
+		total += n
+	}
+	if total != 10 {
+		return nil, fmt.Errorf("invalid formation %q: %d outfield players instead of 10", s, total)
+	}
+
+	formation.Slots = append(formation.Slots, FormationSlot{Line: 0, Position: PositionGoalkeeper, X: 0.05, Y: 0.5})
+	for i, n := range formation.Lines {
+		position := PositionMidfielder
+		switch i {
+		case 0:
+			position = PositionDefender
+		case len(formation.Lines) - 1:
+			position = PositionForward
+		}
+		// Outfield lines are spread between 20% and 80% of the pitch length
+		x := 0.2 + 0.6*float64(i)/float64(max(len(formation.Lines)-1, 1))
+		for j := 0; j < n; j++ {
+			y := float64(j+1) / float64(n+1)
+			formation.Slots = append(formation.Slots, FormationSlot{Line: i + 1, Index: j, Position: position, X: x, Y: y})
+		}
+	}
+	return formation, nil
+}
+
+// Assign fills the slots with the starting players of a lineup, in lineup order,
+// which the API gives from the goalkeeper to the attack. Substitutes are skipped
+func (f *Formation) Assign(lineup []SquadMember) {
+	slot := 0
+	for i := range lineup {
+		if lineup[i].Substitute {
+			continue
+		}
+		if slot == len(f.Slots) {
+			return
+		}
+		player := lineup[i]
+		f.Slots[slot].Player = &player
+		if x, y, ok := player.PitchPosition(); ok {
+			f.Slots[slot].X, f.Slots[slot].Y = x, y
+		}
+		slot++
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean radius of the Earth, in kilometers
+const earthRadiusKm = 6371.0
+
+// HasCoordinates tells if the API gave the location of the venue
+func (v Venue) HasCoordinates() bool {
+	return v.Latitude != nil && v.Longitude != nil
+}
+
+// DistanceKm returns the great-circle distance from the venue to the given point
+// It's false when the venue has no coordinates
+func (v Venue) DistanceKm(lat, lon float64) (float64, bool) {
+	if !v.HasCoordinates() {
+		return 0, false
+	}
+	return haversineKm(*v.Latitude, *v.Longitude, lat, lon), true
+}
+
+// VenueDistance is a venue found by FindVenuesNear, with its distance to the searched point
+type VenueDistance struct {
+	Venue      Venue   `json:"venue"`
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// FindVenuesNear returns the venues within radiusKm of the given point, closest first
+// The API has no geographic search, so the venues are filtered locally, e.g. over the
+// venues of the teams of a tournament. Venues without coordinates are left out
+func FindVenuesNear(venues []Venue, lat, lon, radiusKm float64) []VenueDistance {
+	var found []VenueDistance
+	for _, venue := range venues {
+		if distance, ok := venue.DistanceKm(lat, lon); ok && distance <= radiusKm {
+			found = append(found, VenueDistance{Venue: venue, DistanceKm: distance})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].DistanceKm < found[j].DistanceKm })
+	return found
+}
+
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default max-age of the responses of DataHandler
+const (
+	DefaultDataMaxAge     = 5 * time.Minute
+	DefaultLiveDataMaxAge = 15 * time.Second
+)
+
+// DataHandlerOptions configures DataHandler
+type DataHandlerOptions struct {
+	// Max-age of the responses, DefaultDataMaxAge when zero
+	MaxAge time.Duration
+	// Max-age of the responses of live data (events, live standings), DefaultLiveDataMaxAge when zero
+	LiveMaxAge time.Duration
+	// Cache-Control is public by default, so shared caches and CDNs keep the responses too
+	Private bool
+}
+
+// DataHandler serves data of the client as JSON, for web apps to fetch directly:
+//
+//	GET /standings/{tournamentID}
+//	GET /standings/{tournamentID}/live
+//	GET /fixtures/{tournamentID}    events of the tournament over the next two weeks
+//	GET /events/{eventID}
+//
+// Responses come from the client, so from its cache when they're there, and carry
+// Cache-Control and ETag headers. Mount it under a prefix with http.StripPrefix:
+//
+//	mux.Handle("/api/", http.StripPrefix("/api", c.DataHandler(client.DataHandlerOptions{})))
+func (c *VSportsClient_s) DataHandler(opts DataHandlerOptions) http.Handler {
+	if opts.MaxAge == 0 {
+		opts.MaxAge = DefaultDataMaxAge
+	}
+	if opts.LiveMaxAge == 0 {
+		opts.LiveMaxAge = DefaultLiveDataMaxAge
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /standings/{id}", c.dataRoute(opts, opts.MaxAge, func(ctx context.Context, id int) (any, error) {
+		return c.GetStandingsByTournament(ctx, id)
+	}))
+	mux.Handle("GET /standings/{id}/live", c.dataRoute(opts, opts.LiveMaxAge, func(ctx context.Context, id int) (any, error) {
+		return c.GetStandingsByTournamentLive(ctx, id)
+	}))
+	mux.Handle("GET /fixtures/{id}", c.dataRoute(opts, opts.MaxAge, func(ctx context.Context, id int) (any, error) {
+		today := c.clock.Now().UTC()
+		events, err := c.eventsBetween(ctx, today, today.AddDate(0, 0, upcomingEventsDays), func(event Event) bool {
+			return event.Tournament.ID == id
+		})
+		if events == nil {
+			events = []Event{}
+		}
+		return events, err
+	}))
+	mux.Handle("GET /events/{id}", c.dataRoute(opts, opts.LiveMaxAge, func(ctx context.Context, id int) (any, error) {
+		return c.GetEventById(ctx, id)
+	}))
+	return mux
+}
+
+// Serves the data fetched for the ID of the path
+func (c *VSportsClient_s) dataRoute(opts DataHandlerOptions, maxAge time.Duration, fetch func(ctx context.Context, id int) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil || id <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		data, err := fetch(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				writeJSONError(w, http.StatusNotFound, "not found")
+				return
+			}
+			c.logger.Warn(fmt.Sprintf("Error serving %s: %v", r.URL.Path, err))
+			status := http.StatusBadGateway
+			if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrBudgetExceeded) || errors.Is(err, ErrRateBudgetExceeded) {
+				status = http.StatusServiceUnavailable
+			}
+			writeJSONError(w, status, "upstream unavailable")
+			return
+		}
+		body, err := json.Marshal(data)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("Error encoding %s: %v", r.URL.Path, err))
+			writeJSONError(w, http.StatusInternalServerError, "encoding error")
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		visibility := "public"
+		if opts.Private {
+			visibility = "private"
+		}
+		w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility, int(maxAge.Seconds())))
+		w.Header().Set("ETag", etag)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// Tells if an If-None-Match header lists the ETag, weak validators included
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthStatus is a snapshot of the health of the client and its dependencies
+type HealthStatus struct {
+	// Whether the client can serve requests: an API base URL is in rotation, and the
+	// cache is reachable or the client is allowed to run without it
+	Ready bool `json:"ready"`
+	// Health of the cache, Redis unless another CacheBackend is used
+	Redis RedisHealth `json:"redis"`
+	// Health of each base URL, in order of preference
+	Endpoints []EndpointState `json:"endpoints"`
+	// Circuit breakers of the endpoint groups used so far
+	Breakers []BreakerState `json:"breakers"`
+	// Last time any base URL answered, zero if none did yet
+	LastAPISuccess time.Time `json:"lastApiSuccess,omitempty"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// RedisHealth tells whether the Redis server answered a ping
+// Caches without a server, which don't implement Pinger, are always reachable
+type RedisHealth struct {
+	Reachable bool          `json:"reachable"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Health checks the cache and reports the state of the API base URLs
+// No call is made to the API, its health comes from the requests already made
+func (c *VSportsClient_s) Health(ctx context.Context) HealthStatus {
+	status := HealthStatus{
+		Endpoints: c.EndpointStates(),
+		Breakers:  c.BreakerStates(),
+		CheckedAt: c.clock.Now(),
+	}
+
+	status.Redis.Reachable = true
+	if pinger, ok := c.cache.(Pinger); ok {
+		timeout, _, _ := c.settings.get()
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		start := time.Now()
+		err := pinger.Ping(pingCtx)
+		status.Redis.Latency = time.Since(start)
+		if err != nil {
+			status.Redis.Reachable = false
+			status.Redis.Error = err.Error()
+		}
+	}
+
+	apiUp := false
+	for _, endpoint := range status.Endpoints {
+		if endpoint.Healthy {
+			apiUp = true
+		}
+		if endpoint.LastSuccess.After(status.LastAPISuccess) {
+			status.LastAPISuccess = endpoint.LastSuccess
+		}
+	}
+	status.Ready = apiUp && (status.Redis.Reachable || c.degraded.AllowWithoutCache)
+	return status
+}
+
+// LivenessHandler answers 200 as long as the process can serve HTTP
+// It doesn't look at the dependencies, so an outage upstream doesn't get the service restarted
+func (c *VSportsClient_s) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"live":true}`)
+	})
+}
+
+// ReadinessHandler answers with the HealthStatus of the client as JSON, with a 200
+// status when it's ready to serve requests and a 503 otherwise
+func (c *VSportsClient_s) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := c.Health(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if status.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			c.logger.Error(fmt.Sprintf("Error writing health status: %v", err))
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// A test API counting the calls it gets, answering with handler
+type testAPI struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newTestAPI(t *testing.T, handler http.HandlerFunc) *testAPI {
+	t.Helper()
+	api := &testAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.calls.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// Creates a client of the given base URLs with an in-memory cache, on top of config
+func newTestClient(t *testing.T, config client.ClientConfig, baseURLs ...string) *client.VSportsClient_s {
+	t.Helper()
+	c, err := client.New("test-key",
+		client.WithConfig(config),
+		client.WithBaseURL(baseURLs...),
+		client.WithCache(client.NewMemoryCache(0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// What a request shared by identical requests in flight ends with
+type sharedResult struct {
+	body   []byte
+	record AuditRecord
+}
+
+// Returns the key identical requests in flight share a call under: the cache key, along
+// with the options changing how the response is fetched and stored, so a caller never
+// gets a response fetched under options other than its own. Requests with a backoff of
+// their own can't be told apart and aren't shared
+func shareKey(cacheKey string, o requestOptions, ttl time.Duration) (string, bool) {
+	if o.retryBackoff != nil {
+		return "", false
+	}
+	key := fmt.Sprintf("%s|noCache=%t,refresh=%t,ttl=%s,attempts=%d", cacheKey, o.noCache, o.refresh, ttl, o.maxAttempts)
+	if v := o.conditional(); !v.empty() {
+		key += "|" + v.etag + "|" + v.lastModified
+	}
+	return key, true
+}
+
+// Runs fetch once for all the identical requests in flight, i.e. with the same key, see
+// shareKey, so a burst of misses on a popular resource makes a single upstream call
+// The call outlives the caller that started it, so the others still get its response
+// when that caller gives up, but not its deadline, and each caller only waits for as
+// long as its ctx allows
+// The audit record of the caller that made the call tells how it went, the others
+// are recorded as AuditCacheShared. When the caller that made the call is gone by the
+// time it's done, the call is recorded on its own, so its upstream attempts still are
+func (c *VSportsClient_s) shareRequest(ctx context.Context, key string, record *AuditRecord, fetch func(ctx context.Context, record *AuditRecord) ([]byte, error)) ([]byte, error) {
+	made := false
+	// Whether the call of this caller is done, and whether this caller stopped waiting for it
+	var mu sync.Mutex
+	var finished, abandoned bool
+	results := c.inflight.DoChan(key, func() (any, error) {
+		made = true
+		// A record of its own, as the caller may be gone by the time it's done
+		own := *record
+		callCtx := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithDeadline(callCtx, deadline)
+			defer cancel()
+		}
+		body, err := fetch(callCtx, &own)
+
+		mu.Lock()
+		finished = true
+		gone := abandoned
+		mu.Unlock()
+		if gone {
+			c.audit(own, err)
+		}
+		return sharedResult{body: body, record: own}, err
+	})
+
+	var shared singleflight.Result
+	select {
+	case shared = <-results:
+	case <-ctx.Done():
+		mu.Lock()
+		abandoned = !finished
+		mu.Unlock()
+		if abandoned {
+			return nil, ctx.Err()
+		}
+		// The call is done already, its result is waiting
+		shared = <-results
+	}
+	result, _ := shared.Val.(sharedResult)
+	if made {
+		*record = result.record
+	} else {
+		record.Cache = AuditCacheShared
+	}
+	return result.body, shared.Err
+}
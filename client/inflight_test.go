@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestSharedMissHonoursDeadline(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  5,
+		RetryBackoff: client.ConstantBackoff(200 * time.Millisecond),
+	}, api.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := c.GetRaw(ctx, "teams/1", nil)
+
+	// The second retry can't finish in time, so the last error of the API is returned
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want the 503 of the API", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+}
+
+func TestIdenticalMissesShareACall(t *testing.T) {
+	release := make(chan struct{})
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newTestClient(t, client.ClientConfig{}, api.URL)
+
+	var wg sync.WaitGroup
+	bodies := make([][]byte, 10)
+	errs := make([]error, len(bodies))
+	for i := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bodies[i], errs[i] = c.GetRaw(context.Background(), "teams/1", nil)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range bodies {
+		if errs[i] != nil || string(bodies[i]) != `{"id": 1}` {
+			t.Errorf("caller %d got %q, %v", i, bodies[i], errs[i])
+		}
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestMissesWithOtherOptionsDontShare(t *testing.T) {
+	release := make(chan struct{})
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newTestClient(t, client.ClientConfig{}, api.URL)
+
+	var wg sync.WaitGroup
+	for _, opts := range [][]client.RequestOption{nil, {client.WithNoCache()}, {client.WithTTL(time.Hour)}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetRaw(context.Background(), "teams/1", nil, opts...); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls := api.calls.Load(); calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+}
+
+func TestAbandonedCallIsAudited(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	})
+	records := make(chan client.AuditRecord, 2)
+	c := newTestClient(t, client.ClientConfig{AuditSink: client.AuditFunc(func(record client.AuditRecord) {
+		records <- record
+	})}, api.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := c.GetRaw(ctx, "teams/1", nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	if record := <-records; record.Upstream {
+		t.Errorf("the caller's record has upstream calls: %+v", record)
+	}
+	close(release)
+
+	select {
+	case record := <-records:
+		if len(record.Attempts) != 1 || record.Attempts[0].Status != http.StatusOK {
+			t.Errorf("got attempts %+v, want the call answered 200", record.Attempts)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the call wasn't audited")
+	}
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCacheNotScannable is returned by the cache inspection and invalidation methods
+// when the cache doesn't implement KeyScanner
+var ErrCacheNotScannable = errors.New("cache can't list its keys")
+
+// Prefixes of the cache keys, see cacheKey and staleKey
+const (
+	cacheKeyPrefix      = "vsports://"
+	staleCacheKeyPrefix = "vsports-stale://"
+)
+
+// CacheKey is a key of the client in the cache, broken down into the request it caches
+type CacheKey struct {
+	Key string
+	// As requested, e.g. "events/12/detailed"
+	Endpoint string
+	Params   map[string]string
+	// Locale of the client that cached it, see WithLocale
+	Locale string
+	// The copy kept for DegradedPolicy.ServeStale, rather than the regular entry
+	Stale bool
+}
+
+// CacheEntryInfo describes a cached response
+type CacheEntryInfo struct {
+	CacheKey
+	// Size of the response
+	Size int
+	// When it was fetched and how long it's fresh for, zero for entries cached by
+	// older versions of the client
+	FetchedAt time.Time
+	TTL       time.Duration
+	// Past its TTL, only kept for StaleWhileRevalidateSeconds or ServeStale
+	Expired bool
+}
+
+// Returns the prefixes of the regular and stale keys of the client
+func (c *VSportsClient_s) cacheKeyPrefixes() (regular, stale string) {
+	regular, stale = cacheKeyPrefix, staleCacheKeyPrefix
+	if c.cacheNamespace != "" {
+		regular += c.cacheNamespace + "/"
+		stale += c.cacheNamespace + "/"
+	}
+	return regular, stale
+}
+
+// Breaks a key of the client down, false when it isn't one
+func (c *VSportsClient_s) parseCacheKey(key string) (CacheKey, bool) {
+	regular, stale := c.cacheKeyPrefixes()
+	parsed := CacheKey{Key: key}
+	rest, ok := strings.CutPrefix(key, regular)
+	if !ok {
+		if rest, ok = strings.CutPrefix(key, stale); !ok {
+			return CacheKey{}, false
+		}
+		parsed.Stale = true
+	}
+
+	endpoint, rest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return CacheKey{}, false
+	}
+	parsed.Endpoint = endpoint
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		rest, parsed.Locale = rest[:i], rest[i+1:]
+	}
+	if rest != "" {
+		parsed.Params = map[string]string{}
+		for _, param := range strings.Split(rest, "&") {
+			name, value, _ := strings.Cut(param, "=")
+			parsed.Params[name] = value
+		}
+	}
+	return parsed, true
+}
+
+// CacheKeys lists the keys of the client in the cache, regular and stale ones, sorted
+// A client without a cache namespace also lists the keys of the namespaced clients
+// sharing its cache, with the namespace as the start of their endpoint
+func (c *VSportsClient_s) CacheKeys(ctx context.Context) ([]CacheKey, error) {
+	scanner, ok := c.cache.(KeyScanner)
+	if !ok {
+		return nil, ErrCacheNotScannable
+	}
+	var keys []CacheKey
+	regular, stale := c.cacheKeyPrefixes()
+	for _, prefix := range []string{regular, stale} {
+		found, err := scanner.Keys(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("error listing cache keys: %w", err)
+		}
+		for _, key := range found {
+			if parsed, ok := c.parseCacheKey(key); ok {
+				keys = append(keys, parsed)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return keys, nil
+}
+
+// InspectCache describes the cached response of a key, as listed by CacheKeys
+// A missing entry is not an error: found is false and err is nil
+func (c *VSportsClient_s) InspectCache(ctx context.Context, key string) (info CacheEntryInfo, found bool, err error) {
+	parsed, ok := c.parseCacheKey(key)
+	if !ok {
+		return CacheEntryInfo{}, false, fmt.Errorf("%q isn't a cache key of the client", key)
+	}
+	entry, found, err := c.cacheLookup(ctx, key)
+	if !found || err != nil {
+		return CacheEntryInfo{}, found, err
+	}
+	return CacheEntryInfo{
+		CacheKey:  parsed,
+		Size:      len(entry.body),
+		FetchedAt: entry.fetchedAt,
+		TTL:       entry.ttl,
+		Expired:   entry.expired(c.clock.Now()),
+	}, true, nil
+}
+
+// InvalidateCache deletes the keys of the client matching a filter, stale copies
+// included, and returns how many were deleted. The next requests for them call the API
+func (c *VSportsClient_s) InvalidateCache(ctx context.Context, match func(key CacheKey) bool) (int, error) {
+	keys, err := c.CacheKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var deleted []string
+	for _, key := range keys {
+		if match(key) {
+			deleted = append(deleted, key.Key)
+		}
+	}
+	if err := c.cache.Delete(ctx, deleted...); err != nil {
+		return 0, fmt.Errorf("error deleting cache keys: %w", err)
+	}
+	c.logger.Info(fmt.Sprintf("Invalidated %d cache keys", len(deleted)))
+	return len(deleted), nil
+}
+
+// InvalidateEvent deletes the cached responses of an event, e.g. after it was
+// rescheduled: the event, its details, occurrences, broadcasts and preview, along with
+// every events list, as any of them may hold it
+func (c *VSportsClient_s) InvalidateEvent(ctx context.Context, eventID int) (int, error) {
+	id := strconv.Itoa(eventID)
+	return c.InvalidateCache(ctx, func(key CacheKey) bool {
+		return key.Endpoint == "events" || key.Endpoint == "events/detailed" ||
+			underEndpoint(key.Endpoint, "events/"+id) ||
+			underEndpoint(key.Endpoint, "broadcasts/by/event/"+id) ||
+			key.Endpoint == "preview/"+id
+	})
+}
+
+// InvalidateTournament deletes the cached responses of a tournament: the tournament
+// and the tournaments list, and its teams, standings, squads, broadcasts, top scorers
+// and season events
+func (c *VSportsClient_s) InvalidateTournament(ctx context.Context, tournamentID int) (int, error) {
+	id := strconv.Itoa(tournamentID)
+	return c.InvalidateCache(ctx, func(key CacheKey) bool {
+		return key.Endpoint == "tournaments" || underEndpoint(key.Endpoint, "tournaments/"+id) ||
+			strings.HasSuffix(key.Endpoint, "/by/tournament/"+id) ||
+			strings.Contains(key.Endpoint, "/by/tournament/"+id+"/") ||
+			key.Params[paramTournament] == id
+	})
+}
+
+// InvalidateAll deletes every cached response of the client
+// Clients with a cache namespace only delete theirs, the others delete every key of
+// the client package, namespaced or not
+func (c *VSportsClient_s) InvalidateAll(ctx context.Context) (int, error) {
+	return c.InvalidateCache(ctx, func(key CacheKey) bool { return true })
+}
+
+// Tells if endpoint is base or one of its sub-resources, e.g. "events/12/media" for "events/12"
+func underEndpoint(endpoint, base string) bool {
+	return endpoint == base || strings.HasPrefix(endpoint, base+"/")
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// KeyStrategy decides which key of a KeyPool is used for the next request
+type KeyStrategy string
+
+const (
+	// Use the keys in turn
+	KeyStrategyRoundRobin KeyStrategy = "round-robin"
+	// Use the key with the most requests left, as reported by the API
+	KeyStrategyMostRemaining KeyStrategy = "most-remaining"
+)
+
+// RateLimitObserver is implemented by key providers that want to know the rate-limit
+// state the API reported for the key used in a request
+type RateLimitObserver interface {
+	ObserveRateLimit(key string, state RateLimitState)
+}
+
+// KeyState is the rate-limit state tracked for one key of a KeyPool
+// The key itself is masked so the state can be logged safely
+type KeyState struct {
+	Key       string         `json:"key"`
+	RateLimit RateLimitState `json:"rateLimit"`
+}
+
+// KeyPool is a KeyProvider spreading requests over several API keys
+// The rate-limit state of each key is tracked separately, and keys with no requests
+// left are skipped until their limit resets, as long as another key is available
+type KeyPool struct {
+	strategy KeyStrategy
+	clock    Clock
+
+	mu     sync.Mutex
+	keys   []string
+	states []RateLimitState
+	next   int
+}
+
+// NewKeyPool creates a pool over the given keys
+// An empty strategy means round-robin
+func NewKeyPool(keys []string, strategy KeyStrategy) (*KeyPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key pool needs at least one key")
+	}
+	if strategy == "" {
+		strategy = KeyStrategyRoundRobin
+	}
+	if strategy != KeyStrategyRoundRobin && strategy != KeyStrategyMostRemaining {
+		return nil, fmt.Errorf("unknown key strategy %q", strategy)
+	}
+	for _, key := range keys {
+		if key == "" {
+			return nil, errors.New("key pool contains an empty key")
+		}
+	}
+	return &KeyPool{
+		strategy: strategy,
+		clock:    SystemClock{},
+		keys:     append([]string(nil), keys...),
+		states:   make([]RateLimitState, len(keys)),
+	}, nil
+}
+
+func (p *KeyPool) APIKey(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	var pick int
+	switch p.strategy {
+	case KeyStrategyMostRemaining:
+		pick = p.mostRemaining(now)
+	default:
+		pick = p.roundRobin(now)
+	}
+	return p.keys[pick], nil
+}
+
+// Takes the next key in turn that isn't exhausted
+// If all of them are, the turn order is kept and the API gets to decide
+func (p *KeyPool) roundRobin(now time.Time) int {
+	for i := range p.keys {
+		idx := (p.next + i) % len(p.keys)
+		if !p.states[idx].Exhausted(now) {
+			p.next = idx + 1
+			return idx
+		}
+	}
+	idx := p.next % len(p.keys)
+	p.next = idx + 1
+	return idx
+}
+
+// Takes the key with the most requests left
+// Keys we know nothing about yet (or whose limit has reset) are tried first,
+// ties are broken in turn order
+func (p *KeyPool) mostRemaining(now time.Time) int {
+	const unknown = int(^uint(0) >> 1)
+
+	best, bestRemaining := -1, 0
+	for i := range p.keys {
+		idx := (p.next + i) % len(p.keys)
+		state := p.states[idx]
+		remaining := state.Remaining
+		known := !state.UpdatedAt.IsZero() && remaining >= 0
+		reset := !state.Reset.IsZero() && !now.Before(state.Reset)
+		if !known || reset {
+			remaining = unknown
+		}
+		if best < 0 || remaining > bestRemaining {
+			best, bestRemaining = idx, remaining
+		}
+	}
+
+	// Count the request against the key right away, so concurrent requests
+	// don't all pick the same key before its next response comes in
+	if bestRemaining != unknown && p.states[best].Remaining > 0 {
+		p.states[best].Remaining--
+	}
+	p.next = best + 1
+	return best
+}
+
+// ObserveRateLimit records the state reported by the API for a key of the pool
+func (p *KeyPool) ObserveRateLimit(key string, state RateLimitState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.keys {
+		if p.keys[i] == key {
+			p.states[i] = state
+			return
+		}
+	}
+}
+
+// States returns the tracked rate-limit state of every key in the pool
+func (p *KeyPool) States() []KeyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	states := make([]KeyState, len(p.keys))
+	for i := range p.keys {
+		states[i] = KeyState{Key: maskKey(p.keys[i]), RateLimit: p.states[i]}
+	}
+	return states
+}
+
+// Hides all but the last 4 characters of a key
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// KeyStates returns the rate-limit state of each key when the client uses a KeyPool
+// It returns nil for any other key provider
+func (c *VSportsClient_s) KeyStates() []KeyState {
+	if pool, ok := c.keys.get().(*KeyPool); ok {
+		return pool.States()
+	}
+	return nil
+}
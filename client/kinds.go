@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"slices"
+)
+
+// TeamType tells club teams and national teams apart
+type TeamType string
+
+const (
+	TeamTypeClub     TeamType = "club"
+	TeamTypeNational TeamType = "national"
+)
+
+// CompetitionType tells the kind of a competition
+type CompetitionType string
+
+const (
+	CompetitionLeague   CompetitionType = "league"
+	CompetitionCup      CompetitionType = "cup"
+	CompetitionFriendly CompetitionType = "friendly"
+)
+
+// IsNational tells if the team is a national team
+func (t Team) IsNational() bool {
+	return t.Type == TeamTypeNational
+}
+
+// TeamsOfType keeps the teams of the given types
+func TeamsOfType(teams []Team, types ...TeamType) []Team {
+	var kept []Team
+	for _, team := range teams {
+		if slices.Contains(types, team.Type) {
+			kept = append(kept, team)
+		}
+	}
+	return kept
+}
+
+// TournamentsOfType keeps the tournaments whose competition is of the given types
+func TournamentsOfType(tournaments []Tournament, types ...CompetitionType) []Tournament {
+	var kept []Tournament
+	for _, tournament := range tournaments {
+		if slices.Contains(types, tournament.Competition.Type) {
+			kept = append(kept, tournament)
+		}
+	}
+	return kept
+}
+
+// EventsOfType keeps the events whose competition is of the given types
+func EventsOfType(events []Event, types ...CompetitionType) []Event {
+	var kept []Event
+	for _, event := range events {
+		if slices.Contains(types, event.Tournament.Competition.Type) {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
+// GetTournamentsByType returns the tournaments whose competition is of the given types,
+// e.g. only the leagues. The filtering is done on the list returned by GetTournaments
+func (c *VSportsClient_s) GetTournamentsByType(ctx context.Context, types []CompetitionType, opts ...RequestOption) ([]Tournament, error) {
+	tournaments, err := c.GetTournaments(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return TournamentsOfType(tournaments, types...), nil
+}
+
+// GetTeamsByTournamentIdAndType returns the teams of a tournament of the given types
+func (c *VSportsClient_s) GetTeamsByTournamentIdAndType(ctx context.Context, tournamentID int, types []TeamType, opts ...RequestOption) ([]Team, error) {
+	teams, err := c.GetTeamsByTournamentId(ctx, tournamentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return TeamsOfType(teams, types...), nil
+}
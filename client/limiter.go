@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimitMaxQueue is how many calls wait at once in RateLimitQueue mode when
+// RateLimit.MaxQueue isn't set
+const DefaultRateLimitMaxQueue = 100
+
+// ErrRateBudgetExceeded is returned for upstream calls over the RateLimit of the client
+// that can't wait for their turn: in RateLimitFailFast mode, when the queue is full in
+// RateLimitQueue mode, or when the turn comes after the deadline of the request
+// The last good response is served instead when the client serves stale ones
+var ErrRateBudgetExceeded = errors.New("client rate limit exceeded")
+
+// RateLimitMode is what happens to the upstream calls over the RateLimit
+type RateLimitMode string
+
+const (
+	// Calls wait for their turn, for as long as their context allows
+	RateLimitBlock RateLimitMode = "block"
+	// Calls fail with ErrRateBudgetExceeded rather than waiting
+	RateLimitFailFast RateLimitMode = "fail-fast"
+	// Calls wait for their turn, in order, up to MaxQueue of them. The next ones fail with
+	// ErrRateBudgetExceeded
+	RateLimitQueue RateLimitMode = "queue"
+)
+
+// RateLimit paces the upstream calls of the client with a token bucket, so it stays within
+// the requests per minute of the API contract. Every call counts, retries and failovers
+// included, but not the responses served from the cache. Clients of a ClientManager share it
+type RateLimit struct {
+	// Calls allowed per minute, on average. Zero disables the limiter
+	RequestsPerMinute int `json:"requestsPerMinute"`
+	// Calls allowed at once after a quiet period, 1 when zero
+	Burst int `json:"burst"`
+	// RateLimitBlock when empty
+	Mode RateLimitMode `json:"mode"`
+	// Calls waiting at once in RateLimitQueue mode, DefaultRateLimitMaxQueue when zero
+	MaxQueue int `json:"maxQueue"`
+}
+
+// RateBudget is the usage of the RateLimit of the client, e.g. for schedulers to spread
+// their work. It's zero when the client has no RateLimit
+type RateBudget struct {
+	RequestsPerMinute int `json:"requestsPerMinute"`
+	Burst             int `json:"burst"`
+	// Calls that can be made right away, negative when calls are waiting for their turn
+	Available float64 `json:"available"`
+	// How long a call made now would wait for its turn
+	Wait time.Duration `json:"waitNs"`
+	// Calls waiting for their turn
+	Waiting int `json:"waiting"`
+	// Calls let through and refused since the client was created
+	Allowed  int64 `json:"allowed"`
+	Rejected int64 `json:"rejected"`
+}
+
+type rateLimiter struct {
+	config  RateLimit
+	limiter *rate.Limiter
+
+	mu       sync.Mutex
+	waiting  int
+	allowed  int64
+	rejected int64
+}
+
+// Returns nil when the config disables the limiter
+func newRateLimiter(config RateLimit) *rateLimiter {
+	if config.RequestsPerMinute <= 0 {
+		return nil
+	}
+	if config.Burst <= 0 {
+		config.Burst = 1
+	}
+	if config.Mode == "" {
+		config.Mode = RateLimitBlock
+	}
+	if config.MaxQueue <= 0 {
+		config.MaxQueue = DefaultRateLimitMaxQueue
+	}
+	return &rateLimiter{
+		config:  config,
+		limiter: rate.NewLimiter(rate.Limit(float64(config.RequestsPerMinute)/60), config.Burst),
+	}
+}
+
+// Waits for the turn of an upstream call, as the mode allows
+// The limiter is driven by the clock of the client rather than its own
+func (l *rateLimiter) wait(ctx context.Context, clock Clock) error {
+	if l == nil {
+		return nil
+	}
+	now := clock.Now()
+	reservation := l.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		l.count(&l.allowed)
+		return nil
+	}
+
+	refuse := func(format string, args ...any) error {
+		reservation.CancelAt(now)
+		l.count(&l.rejected)
+		return fmt.Errorf("%w: "+format, append([]any{ErrRateBudgetExceeded}, args...)...)
+	}
+	if l.config.Mode == RateLimitFailFast {
+		return refuse("next call in %s", delay.Round(time.Millisecond))
+	}
+	if !deadlineAllows(ctx, delay) {
+		return refuse("next call in %s, past the deadline", delay.Round(time.Millisecond))
+	}
+
+	l.mu.Lock()
+	if l.config.Mode == RateLimitQueue && l.waiting >= l.config.MaxQueue {
+		l.mu.Unlock()
+		return refuse("%d calls queued already", l.config.MaxQueue)
+	}
+	l.waiting++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.waiting--
+	}()
+
+	if err := sleepContext(ctx, clock, delay); err != nil {
+		// Give the turn back to the calls behind
+		reservation.CancelAt(clock.Now())
+		return err
+	}
+	l.count(&l.allowed)
+	return nil
+}
+
+func (l *rateLimiter) count(counter *int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*counter++
+}
+
+func (l *rateLimiter) budget(now time.Time) RateBudget {
+	if l == nil {
+		return RateBudget{}
+	}
+	available := l.limiter.TokensAt(now)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	budget := RateBudget{
+		RequestsPerMinute: l.config.RequestsPerMinute,
+		Burst:             l.config.Burst,
+		Available:         available,
+		Waiting:           l.waiting,
+		Allowed:           l.allowed,
+		Rejected:          l.rejected,
+	}
+	if available < 1 {
+		budget.Wait = time.Duration((1 - available) / float64(l.limiter.Limit()) * float64(time.Second))
+	}
+	return budget
+}
+
+// RateBudget returns the usage of the RateLimit of the client
+func (c *VSportsClient_s) RateBudget() RateBudget {
+	return c.limiter.budget(c.clock.Now())
+}
@@ -0,0 +1,111 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// A client allowed one call per second, and a getter of distinct teams, so nothing is cached
+func newLimitedClient(t *testing.T, limit client.RateLimit) (*client.ManualClock, func(id string) error, *client.VSportsClient_s) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	limit.RequestsPerMinute = 60
+	c := newTestClient(t, client.ClientConfig{Clock: clock, MaxAttempts: 1, RateLimit: limit}, api.URL)
+	get := func(id string) error {
+		_, err := c.GetRaw(context.Background(), "teams/"+id, nil)
+		return err
+	}
+	return clock, get, c
+}
+
+// Waits for n calls to be waiting for their turn
+func waitForQueue(t *testing.T, c *client.VSportsClient_s, n int) {
+	t.Helper()
+	timeout := time.Now().Add(5 * time.Second)
+	for c.RateBudget().Waiting != n {
+		if time.Now().After(timeout) {
+			t.Fatalf("got %d calls waiting, want %d", c.RateBudget().Waiting, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Moves the clock along until the call waiting for its turn is done
+func advanceUntilDone(t *testing.T, clock *client.ManualClock, done <-chan error) error {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-timeout:
+			t.Fatal("call still waiting")
+		case <-time.After(time.Millisecond):
+			clock.Advance(100 * time.Millisecond)
+		}
+	}
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	clock, get, c := newLimitedClient(t, client.RateLimit{Mode: client.RateLimitFailFast})
+
+	if err := get("1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := get("2"); !errors.Is(err, client.ErrRateBudgetExceeded) {
+		t.Fatalf("got %v, want ErrRateBudgetExceeded", err)
+	}
+	clock.Advance(time.Second)
+	if err := get("3"); err != nil {
+		t.Fatal(err)
+	}
+	if budget := c.RateBudget(); budget.Allowed != 2 || budget.Rejected != 1 {
+		t.Errorf("got %+v, want 2 calls allowed and 1 rejected", budget)
+	}
+}
+
+func TestRateLimitBlocks(t *testing.T) {
+	clock, get, c := newLimitedClient(t, client.RateLimit{Mode: client.RateLimitBlock})
+
+	if err := get("1"); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- get("2") }()
+	waitForQueue(t, c, 1)
+	select {
+	case err := <-done:
+		t.Fatalf("call made before its turn: %v", err)
+	default:
+	}
+
+	if err := advanceUntilDone(t, clock, done); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimitQueueFull(t *testing.T) {
+	clock, get, c := newLimitedClient(t, client.RateLimit{Mode: client.RateLimitQueue, MaxQueue: 1})
+
+	if err := get("1"); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- get("2") }()
+	waitForQueue(t, c, 1)
+
+	// No room left in the queue
+	if err := get("3"); !errors.Is(err, client.ErrRateBudgetExceeded) {
+		t.Fatalf("got %v, want ErrRateBudgetExceeded", err)
+	}
+	if err := advanceUntilDone(t, clock, done); err != nil {
+		t.Fatal(err)
+	}
+}
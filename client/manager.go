@@ -0,0 +1,113 @@
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// TenantConfig holds the settings that differ between the tenants of a ClientManager
+type TenantConfig struct {
+	APIKey      string      `json:"apiKey"`
+	APIKeys     []string    `json:"apiKeys"`
+	KeyStrategy KeyStrategy `json:"keyStrategy"`
+	Locale      string      `json:"locale"`
+	// Leave empty to share cached responses with the other tenants using the same locale
+	CacheNamespace string `json:"cacheNamespace"`
+}
+
+// ClientManager holds one client per tenant, for services serving several customers
+// All the clients share the HTTP transport, the Redis connection, the health of the
+// base URLs and the hard caps on upstream calls, while each tenant has its own keys,
+// locale, cache namespace and usage stats
+type ClientManager struct {
+	base   ClientConfig
+	logger *slog.Logger
+	shared *sharedResources
+
+	mu      sync.RWMutex
+	clients map[string]*VSportsClient_s
+}
+
+// NewClientManager creates a manager whose clients are built from the base config
+// The base config doesn't need an API key, as every tenant brings its own
+func NewClientManager(base ClientConfig, logger *slog.Logger) (*ClientManager, error) {
+	if logger == nil {
+		logger = slog.New(&noopLogger{})
+	}
+
+	base = base.WithDefaults()
+	if err := base.validate(false); err != nil {
+		return nil, err
+	}
+
+	shared, err := newSharedResources(base, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientManager{
+		base:    base,
+		logger:  logger,
+		shared:  shared,
+		clients: map[string]*VSportsClient_s{},
+	}, nil
+}
+
+// AddTenant creates the client of a tenant, replacing any previous one with the same ID
+func (m *ClientManager) AddTenant(tenantID string, tenant TenantConfig) (*VSportsClient_s, error) {
+	config := m.base
+	config.APIKey = tenant.APIKey
+	config.APIKeys = tenant.APIKeys
+	config.KeyStrategy = tenant.KeyStrategy
+	config.Locale = tenant.Locale
+	config.CacheNamespace = tenant.CacheNamespace
+
+	// Keys only come from the tenant. The base credentials are only used for Redis
+	config.APIKeyFile = ""
+	config.Credentials = nil
+	config.OAuth2 = nil
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", tenantID, err)
+	}
+
+	client, err := newClient(config, m.logger.With("tenant", tenantID), m.shared)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", tenantID, err)
+	}
+
+	m.mu.Lock()
+	m.clients[tenantID] = client
+	m.mu.Unlock()
+	return client, nil
+}
+
+// Client returns the client of a tenant
+func (m *ClientManager) Client(tenantID string) (*VSportsClient_s, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.clients[tenantID]
+	return client, ok
+}
+
+// RemoveTenant forgets the client of a tenant
+// Requests already made with it are not affected
+func (m *ClientManager) RemoveTenant(tenantID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, tenantID)
+}
+
+// Tenants returns the IDs of all tenants, sorted
+func (m *ClientManager) Tenants() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tenants := make([]string, 0, len(m.clients))
+	for tenantID := range m.clients {
+		tenants = append(tenants, tenantID)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxMediaBytes is the size limit applied to media downloads
+// when ClientConfig.MaxMediaBytes is not set
+const DefaultMaxMediaBytes int64 = 100 << 20 // 100 MiB
+
+// How many times an interrupted media transfer is resumed before giving up
+const mediaMaxResumes = 3
+
+// ErrMediaTooLarge is returned when a media file exceeds the configured size limit
+var ErrMediaTooLarge = errors.New("media exceeds maximum allowed size")
+
+// ErrMediaChanged is returned when a media file changed while being downloaded, so the
+// interrupted transfer can't be resumed without mixing two versions of it
+var ErrMediaChanged = errors.New("media changed during download")
+
+// The version of a media file being downloaded, from the first response, so a resumed
+// transfer only goes on with the same one
+type mediaVersion struct {
+	validators validators
+	// Full size, -1 when unknown
+	size int64
+}
+
+// Value of the If-Range header of a resume. Only a strong ETag will do, so a weak one
+// falls back to the Last-Modified date
+func (v mediaVersion) ifRange() string {
+	if v.validators.etag != "" && !strings.HasPrefix(v.validators.etag, "W/") {
+		return v.validators.etag
+	}
+	return v.validators.lastModified
+}
+
+// Tells if a response with the given validators and full size is of another version
+func (v mediaVersion) changed(current validators, size int64) bool {
+	if v.size >= 0 && size >= 0 && v.size != size {
+		return true
+	}
+	switch {
+	case v.validators.etag != "" && current.etag != "":
+		return v.validators.etag != current.etag
+	case v.validators.lastModified != "" && current.lastModified != "":
+		return v.validators.lastModified != current.lastModified
+	}
+	return false
+}
+
+// MediaURL returns the downloadable URL of the media entry
+// Older payloads only carry the embed field, which holds a plain URL for images
+func (m Media_s) MediaURL() string {
+	if m.URL != "" {
+		return m.URL
+	}
+	embed := strings.TrimSpace(m.EmbedCode)
+	if strings.HasPrefix(embed, "http://") || strings.HasPrefix(embed, "https://") {
+		return embed
+	}
+	return ""
+}
+
+// DownloadMedia streams the content of a media entry into w and returns the number of bytes written
+// Interrupted transfers are resumed with range requests, and the download is aborted with
+// ErrMediaTooLarge once it goes over the configured size limit, or with ErrMediaChanged
+// when the media changed before the transfer could be resumed
+func (c *VSportsClient_s) DownloadMedia(ctx context.Context, media Media_s, w io.Writer) (int64, error) {
+	mediaURL := media.MediaURL()
+	if mediaURL == "" {
+		return 0, fmt.Errorf("media %d has no downloadable URL", media.ID)
+	}
+
+	timeout, _, limit := c.settings.get()
+	if limit <= 0 {
+		limit = DefaultMaxMediaBytes
+	}
+
+	var written int64
+	version := mediaVersion{size: -1}
+	var lastErr error
+	var delay time.Duration
+	c.retries.request(c.clock.Now())
+	for attempt := 0; attempt <= mediaMaxResumes; attempt++ {
+		if attempt > 0 {
+			if !c.retries.allowRetry(c.clock.Now()) {
+				c.logger.Warn(fmt.Sprintf("Retry budget exhausted, not resuming media download %s", mediaURL))
+				return written, fmt.Errorf("%w while resuming media download: %w", ErrRetryBudgetExhausted, lastErr)
+			}
+			delay = c.retryBackoff.Delay(attempt, delay)
+			// Don't wait for an attempt that can't finish in time anyway
+			if !deadlineAllows(ctx, delay) {
+				c.logger.Debug(fmt.Sprintf("Not resuming media download %s, the deadline is too close", mediaURL))
+				return written, fmt.Errorf("error downloading media: %w", lastErr)
+			}
+			if err := sleepContext(ctx, c.clock, delay); err != nil {
+				return written, fmt.Errorf("error downloading media: %w", lastErr)
+			}
+			c.logger.Debug(fmt.Sprintf("Resuming media download %s at byte %d (attempt %d): %v", mediaURL, written, attempt, lastErr))
+			c.observe(func(o Observer) {
+				info := RequestInfo{Endpoint: mediaURL, Route: "media", Group: "media"}
+				o.OnRetry(ctx, RetryInfo{Request: info, Attempt: attempt, Reason: RetryMediaResume, Err: lastErr})
+			})
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		n, retry, err := c.downloadMediaRange(attemptCtx, mediaURL, written, limit, &version, w)
+		cancel()
+		written += n
+		if err == nil {
+			return written, nil
+		}
+		if !retry || ctx.Err() != nil {
+			return written, attemptError(ctx, err, lastErr)
+		}
+		lastErr = err
+	}
+
+	c.logger.Error(fmt.Sprintf("Giving up on media download %s after %d attempts: %v", mediaURL, mediaMaxResumes+1, lastErr))
+	return written, fmt.Errorf("error downloading media: %w", lastErr)
+}
+
+// Fetches the media starting at offset and copies it into w. The version of the media
+// is set from the first response, and the others must be of the same one
+// The returned boolean tells whether the error is transient and the transfer can be resumed
+func (c *VSportsClient_s) downloadMediaRange(ctx context.Context, mediaURL string, offset, limit int64, version *mediaVersion, w io.Writer) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", mediaURL, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("error creating media request: %w", err)
+	}
+
+	// Only send our credentials to the provider's own hosts, never to third-party CDNs
+	if c.isProviderHost(req.URL) {
+		apiKey, err := c.keys.apiKey(ctx)
+		if err != nil {
+			return 0, false, fmt.Errorf("error getting API key: %w", err)
+		}
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+		if c.signer != nil {
+			if err := c.signer.Sign(req); err != nil {
+				return 0, false, fmt.Errorf("error signing media request: %w", err)
+			}
+		}
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		// The server sends the whole media instead when it changed
+		if ifRange := version.ifRange(); ifRange != "" {
+			req.Header.Set("If-Range", ifRange)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, true, fmt.Errorf("error making media request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Full size of the media when known, and how much of the body we already have
+	size, skip := int64(-1), int64(0)
+	current := validatorsOf(resp)
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		start, total, ok := parseContentRange(contentRange)
+		if !ok || start != offset {
+			return 0, false, fmt.Errorf("%w: asked for the media from byte %d, got range %q", ErrMediaChanged, offset, contentRange)
+		}
+		size = total
+		if size < 0 && resp.ContentLength >= 0 {
+			size = offset + resp.ContentLength
+		}
+		if version.changed(current, size) {
+			return 0, false, fmt.Errorf("%w: resumed at byte %d of another version", ErrMediaChanged, offset)
+		}
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range header, or the media changed, and sent it whole
+		size, skip = resp.ContentLength, offset
+		if offset > 0 && version.changed(current, size) {
+			return 0, false, fmt.Errorf("%w: got another version when resuming at byte %d", ErrMediaChanged, offset)
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// Nothing left to download
+		return 0, false, nil
+	default:
+		return 0, resp.StatusCode >= 500, fmt.Errorf("unexpected status downloading media: %s", resp.Status)
+	}
+
+	if offset == 0 {
+		*version = mediaVersion{validators: current, size: size}
+	}
+	if size > limit {
+		return 0, false, ErrMediaTooLarge
+	}
+	if skip > 0 {
+		if _, err := io.CopyN(io.Discard, resp.Body, skip); err != nil {
+			return 0, true, fmt.Errorf("error skipping already downloaded media: %w", err)
+		}
+	}
+
+	n, err := io.Copy(&mediaWriter{w}, io.LimitReader(resp.Body, limit-offset))
+	if err == nil && offset+n == limit {
+		// Probe for one more byte so oversized bodies without a content length are detected
+		if extra, _ := resp.Body.Read(make([]byte, 1)); extra > 0 {
+			return n, false, ErrMediaTooLarge
+		}
+	}
+	if err != nil {
+		var we *writeError
+		if errors.As(err, &we) {
+			return n, false, err
+		}
+		return n, true, fmt.Errorf("error reading media body: %w", err)
+	}
+	return n, false, nil
+}
+
+// Parses a Content-Range header, e.g. "bytes 100-199/1000", returning the first byte and
+// the full size, -1 when unknown as in "bytes 100-199/*"
+func parseContentRange(header string) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	byteRange, total, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	first, _, ok := strings.Cut(byteRange, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if total == "*" {
+		return start, -1, true
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, size, true
+}
+
+// Checks if the given URL belongs to the same site as one of the API base URLs, over the
+// same scheme, so credentials never go in clear to a host reached over https otherwise
+func (c *VSportsClient_s) isProviderHost(u *url.URL) bool {
+	for _, endpoint := range c.endpoints.snapshot() {
+		base, err := url.Parse(endpoint.URL)
+		if err != nil || u.Scheme != base.Scheme {
+			continue
+		}
+		host, baseHost := u.Hostname(), base.Hostname()
+		if host == baseHost {
+			return true
+		}
+		// Compare against the parent domain, so extended.vsports.pt trusts media.vsports.pt
+		if i := strings.Index(baseHost, "."); i >= 0 && strings.Count(baseHost[i+1:], ".") >= 1 && strings.HasSuffix(host, baseHost[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wraps the destination writer so its failures can be told apart from network failures
+// A failing destination can't be fixed by resuming the transfer
+type mediaWriter struct {
+	w io.Writer
+}
+
+type writeError struct {
+	err error
+}
+
+func (e *writeError) Error() string { return fmt.Sprintf("error writing media: %v", e.err) }
+func (e *writeError) Unwrap() error { return e.err }
+
+func (mw *mediaWriter) Write(p []byte) (int, error) {
+	n, err := mw.w.Write(p)
+	if err != nil {
+		return n, &writeError{err}
+	}
+	return n, nil
+}
@@ -0,0 +1,174 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// A media server cutting the first transfer halfway, answering range requests with
+// If-Range like a real server. version changes the ETag and content of the media
+type mediaServer struct {
+	mu       sync.Mutex
+	version  int
+	requests []*http.Request
+}
+
+func (s *mediaServer) content() (string, []byte) {
+	return fmt.Sprintf(`"v%d"`, s.version), bytes.Repeat([]byte{byte('a' + s.version)}, 1000)
+}
+
+func (s *mediaServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	first := len(s.requests) == 1
+	etag, content := s.content()
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", etag)
+	rangeHeader := r.Header.Get("Range")
+	if rangeHeader == "" || (r.Header.Get("If-Range") != "" && r.Header.Get("If-Range") != etag) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if first {
+			w.Write(content[:500])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		w.Write(content)
+		return
+	}
+	start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+	w.WriteHeader(http.StatusPartialContent)
+	w.Write(content[start:])
+}
+
+// Publishes a new version of the media before the download is resumed
+type newVersionObserver struct {
+	client.NopObserver
+	s *mediaServer
+}
+
+func (o newVersionObserver) OnRetry(ctx context.Context, info client.RetryInfo) {
+	o.s.mu.Lock()
+	defer o.s.mu.Unlock()
+	o.s.version++
+}
+
+func newMediaClient(t *testing.T, s *mediaServer) (*client.VSportsClient_s, string) {
+	api := newTestAPI(t, s.serve)
+	c := newTestClient(t, client.ClientConfig{RetryBackoff: client.ConstantBackoff(0)}, api.URL)
+	return c, api.URL + "/media/1.jpg"
+}
+
+func TestDownloadMediaResumesSameVersion(t *testing.T) {
+	s := &mediaServer{}
+	c, mediaURL := newMediaClient(t, s)
+
+	var buf bytes.Buffer
+	n, err := c.DownloadMedia(context.Background(), client.Media_s{ID: 1, URL: mediaURL}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, content := s.content(); n != 1000 || !bytes.Equal(buf.Bytes(), content) {
+		t.Errorf("got %d bytes, want the 1000 of the media", n)
+	}
+	if len(s.requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(s.requests))
+	}
+	resume := s.requests[1]
+	if resume.Header.Get("Range") != "bytes=500-" || resume.Header.Get("If-Range") != `"v0"` {
+		t.Errorf("resumed with Range %q and If-Range %q", resume.Header.Get("Range"), resume.Header.Get("If-Range"))
+	}
+}
+
+func TestDownloadMediaChangedWhileResuming(t *testing.T) {
+	s := &mediaServer{}
+	c, mediaURL := newMediaClient(t, s)
+	c.AddObserver(newVersionObserver{s: s})
+
+	var buf bytes.Buffer
+	n, err := c.DownloadMedia(context.Background(), client.Media_s{ID: 1, URL: mediaURL}, &buf)
+	if !errors.Is(err, client.ErrMediaChanged) {
+		t.Fatalf("got %v, want ErrMediaChanged", err)
+	}
+	if n != 500 || bytes.ContainsRune(buf.Bytes(), 'b') {
+		t.Errorf("got %d bytes, want the 500 of the first version only", n)
+	}
+}
+
+func TestDownloadMediaWrongContentRange(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Content-Length", "1000")
+			w.Write(make([]byte, 500))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		// Not where the download stopped
+		w.Header().Set("Content-Range", "bytes 0-999/1000")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(make([]byte, 1000))
+	})
+	c := newTestClient(t, client.ClientConfig{RetryBackoff: client.ConstantBackoff(0)}, api.URL)
+
+	_, err := c.DownloadMedia(context.Background(), client.Media_s{ID: 1, URL: api.URL + "/media/1.jpg"}, &bytes.Buffer{})
+	if !errors.Is(err, client.ErrMediaChanged) {
+		t.Fatalf("got %v, want ErrMediaChanged", err)
+	}
+}
+
+func TestDownloadMediaCredentials(t *testing.T) {
+	var mu sync.Mutex
+	authorized := map[string]bool{}
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authorized[r.Host] = r.Header.Get("Authorization") != ""
+		mu.Unlock()
+		w.Write([]byte("media"))
+	})
+	// Every host resolves to the test server
+	dialer := &net.Dialer{}
+	httpClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, api.Listener.Addr().String())
+		},
+	}}
+	c, err := client.New("test-key",
+		client.WithBaseURL("https://api.example.com", "http://mirror.example.net"),
+		client.WithHTTPClient(httpClient),
+		client.WithCache(client.NewMemoryCache(0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for mediaURL, want := range map[string]bool{
+		// Over http while the API is reached over https
+		"http://media.example.com/1.jpg": false,
+		// On the site of the failover base URL
+		"http://media.mirror.example.net/1.jpg": true,
+		"http://cdn.example.org/1.jpg":          false,
+	} {
+		mu.Lock()
+		authorized = map[string]bool{}
+		mu.Unlock()
+		if _, err := c.DownloadMedia(context.Background(), client.Media_s{ID: 1, URL: mediaURL}, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+		mu.Lock()
+		if got, ok := authorized[mediaURL[len("http://"):strings.Index(mediaURL, "/1.jpg")]]; !ok || got != want {
+			t.Errorf("%s: got credentials %t, want %t", mediaURL, got, want)
+		}
+		mu.Unlock()
+	}
+}
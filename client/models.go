@@ -1,9 +1,44 @@
 package client
 
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+type CareerEntry struct {
+	Team        Team       `json:"team"`
+	Tournament  Tournament `json:"tournament,omitempty"`
+	Season      string     `json:"season"`
+	StartDate   string     `json:"start_date,omitempty"`
+	EndDate     string     `json:"end_date,omitempty"`
+	Appearances int        `json:"appearances"`
+	Goals       int        `json:"goals"`
+	OnLoan      bool       `json:"on_loan,omitempty"`
+}
+
+type Broadcast struct {
+	Broadcaster string    `json:"broadcaster"`
+	Type        string    `json:"type,omitempty"` // tv, streaming or radio
+	Channel     TVChannel `json:"channel,omitempty"`
+	Country     Country   `json:"country,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	// Set in listings covering several events
+	EventID int `json:"event_id,omitempty"`
+}
+
+// Broadcasts available in a country
+type BroadcastListing struct {
+	Country    Country     `json:"country"`
+	Broadcasts []Broadcast `json:"broadcasts"`
+}
+
 type Competition struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Gender string `json:"gender"`
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Gender string          `json:"gender"`
+	Sport  string          `json:"sport,omitempty"`
+	Type   CompetitionType `json:"type,omitempty"`
 }
 
 type Country struct {
@@ -13,10 +48,11 @@ type Country struct {
 }
 
 type Event struct {
-	ID          int          `json:"id"`
-	DateUTC     string       `json:"date_utc"`
-	TimeUTC     string       `json:"time_utc"`
-	DateTime    string       `json:"date_time"`
+	ID      int    `json:"id"`
+	DateUTC string `json:"date_utc"`
+	TimeUTC string `json:"time_utc"`
+	// When the event starts, with the offset given by the API, zero when it gave none
+	DateTime    time.Time    `json:"date_time"`
 	TeamA       Team         `json:"team_A"`
 	TeamB       Team         `json:"team_B"`
 	Tournament  Tournament   `json:"tournament"`
@@ -28,6 +64,8 @@ type Event struct {
 	FS_B        int          `json:"fs_B"`
 	Total_A     int          `json:"total_A"`
 	Total_B     int          `json:"total_B"`
+	PS_A        int          `json:"ps_A,omitempty"`
+	PS_B        int          `json:"ps_B,omitempty"`
 	Minute      int          `json:"minute"`
 	MinuteExtra int          `json:"minute_extra"`
 	MatchLength string       `json:"match_length"`
@@ -38,6 +76,44 @@ type Event struct {
 	Venue       Venue        `json:"venue"`
 	TVChannel   []TVChannel  `json:"tv_channel,omitempty"`
 	Occurrence  []Occurrence `json:"occurrence,omitempty"`
+	Provenance  *Provenance  `json:"-"`
+	// Only in detailed events, when known
+	Attendance int         `json:"attendance,omitempty"`
+	Broadcasts []Broadcast `json:"broadcasts,omitempty"`
+	Officials  []Official  `json:"officials,omitempty"`
+	// Where to buy tickets, and the page of the match on the organizer's site
+	TicketsURL  string `json:"tickets_url,omitempty"`
+	OfficialURL string `json:"official_url,omitempty"`
+	// Sport of the event, see SportOf. Football when empty
+	Sport string `json:"sport,omitempty"`
+	// Score of each period, for sports played in quarters or halves
+	PeriodScores []PeriodScore `json:"period_scores,omitempty"`
+	// Sport-specific statistics of a detailed event, decoded by the Statistics methods
+	Statistics json.RawMessage `json:"statistics,omitempty"`
+	// Only in detailed events of matches decided on penalties
+	Shootout *PenaltyShootout `json:"shootout,omitempty"`
+	// Data added by the enrichers of the client, by enricher name
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Colors are hex codes, e.g. "#ff0000"
+type Kit struct {
+	Type       string `json:"type"` // home, away, third or goalkeeper
+	Shirt      string `json:"shirt"`
+	Shorts     string `json:"shorts"`
+	Socks      string `json:"socks"`
+	ShirtImage string `json:"shirt_image,omitempty"`
+}
+
+type GroupStandings struct {
+	Name      string          `json:"name"` // e.g. "Group A"
+	Standings []StandingEntry `json:"standings"`
+	// Positions qualifying for the next stage, and any other zone of the group
+	Zones []StandingZone `json:"zones,omitempty"`
+	// Qualification and tie-break rules as described by the API
+	QualificationRules string `json:"qualification_rules,omitempty"`
+	// Name of the stage of the group, set by GetGroupStandings
+	Stage string `json:"stage,omitempty"`
 }
 
 type Lineup struct {
@@ -47,6 +123,12 @@ type Lineup struct {
 	TeamBLineup  []SquadMember `json:"team_B_lineup"`
 }
 
+// Coordinates of a player on the pitch, in percent of its length and width
+type PitchCoordinates struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
 type Platform struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -56,11 +138,46 @@ type Media_s struct {
 	ID          int      `json:"id"`
 	ContentType string   `json:"content_type"`
 	EmbedCode   string   `json:"embed"`
+	URL         string   `json:"url,omitempty"`
 	Created     string   `json:"created"`
 	Modified    string   `json:"modified"`
 	Platform    Platform `json:"platform"`
 }
 
+// Roles of match officials
+const (
+	OfficialReferee      = "referee"
+	OfficialAssistant    = "assistant_referee"
+	OfficialFourth       = "fourth_official"
+	OfficialVAR          = "var"
+	OfficialAssistantVAR = "assistant_var"
+)
+
+// Person.ID is the ID of the referee endpoint, see GetRefereeById
+type Official struct {
+	Person
+	Role string `json:"role"`
+}
+
+// OfficialsByRole returns the officials of the event with the given role, e.g. OfficialAssistant
+func (e Event) OfficialsByRole(role string) []Official {
+	var officials []Official
+	for _, official := range e.Officials {
+		if official.Role == role {
+			officials = append(officials, official)
+		}
+	}
+	return officials
+}
+
+// Referee returns the referee of the event, nil when unknown
+func (e Event) Referee() *Official {
+	if referees := e.OfficialsByRole(OfficialReferee); len(referees) > 0 {
+		return &referees[0]
+	}
+	return nil
+}
+
 type Occurrence struct {
 	ID           int       `json:"id"`
 	MatchPeriod  int       `json:"match_period"`
@@ -81,17 +198,52 @@ type Occurrence struct {
 	TeamBScore   *int      `json:"team_B_score,omitempty"`
 	VarType      string    `json:"var_type,omitempty"`
 	VarDecision  string    `json:"var_decision,omitempty"`
+	VarOriginal  string    `json:"var_original_decision,omitempty"`
+	VarReason    string    `json:"var_reason,omitempty"`
 	Outcome      string    `json:"outcome,omitempty"`
+	// Last update of the occurrence, when the API gives it, see MergeOccurrences
+	Modified string `json:"modified,omitempty"`
 }
 
 // type OccurrenceResponse = []Occurrence_s
 
+// Outcomes of a penalty kick
+const (
+	PenaltyScored = "scored"
+	PenaltySaved  = "saved"
+	PenaltyMissed = "missed" // off target or hit the woodwork
+)
+
+type PenaltyKick struct {
+	Order   int    `json:"order"`
+	Team    Team   `json:"team"`
+	Player  Person `json:"player"`
+	Outcome string `json:"outcome"`
+}
+
+type PenaltyShootout struct {
+	TeamAScore int           `json:"team_A_score"`
+	TeamBScore int           `json:"team_B_score"`
+	Kicks      []PenaltyKick `json:"kicks"`
+}
+
+// DecidedOnPenalties tells if the match went to a penalty shootout
+func (e Event) DecidedOnPenalties() bool {
+	return e.Shootout != nil || e.PS_A > 0 || e.PS_B > 0
+}
+
 type Period struct {
 	Period int    `json:"period"`
 	Start  string `json:"start"`
 	End    string `json:"end"`
 }
 
+type PeriodScore struct {
+	Period int `json:"period"`
+	TeamA  int `json:"team_A"`
+	TeamB  int `json:"team_B"`
+}
+
 type Person struct {
 	ID          int     `json:"id,omitempty"`
 	FirstName   string  `json:"first_name"`
@@ -108,9 +260,10 @@ type Person struct {
 }
 
 type Squad struct {
-	ID    int           `json:"id"`
-	Team  Team          `json:"team"`
-	Squad []SquadMember `json:"squad"`
+	ID         int           `json:"id"`
+	Team       Team          `json:"team"`
+	Squad      []SquadMember `json:"squad"`
+	Provenance *Provenance   `json:"-"`
 }
 
 type SquadMember struct {
@@ -124,6 +277,8 @@ type SquadMember struct {
 	Number      int    `json:"number,omitempty"`
 	Photo       string `json:"photo,omitempty"`
 	Substitute  bool   `json:"substitute,omitempty"`
+	// Place on the pitch in lineups, when the API gives it, see PitchPosition
+	Coordinates *PitchCoordinates `json:"coordinates,omitempty"`
 }
 
 type Stage struct {
@@ -133,6 +288,9 @@ type Stage struct {
 	EndDate      string          `json:"end_date"`
 	HasStandings bool            `json:"has_standings,omitempty"`
 	Standings    []StandingEntry `json:"standings,omitempty"`
+	Zones        []StandingZone  `json:"zones,omitempty"`
+	// Stages played in groups have a table per group instead of Standings
+	Groups []GroupStandings `json:"groups,omitempty"`
 }
 
 type StandingEntry struct {
@@ -147,6 +305,65 @@ type StandingEntry struct {
 	GoalsAgainst   int  `json:"goals_against"`
 	GoalDifference int  `json:"goal_difference"`
 	Team           Team `json:"team"`
+
+	zone *StandingZone
+}
+
+// Zone returns the zone of the table the entry is in, nil when it's in none
+func (e StandingEntry) Zone() *StandingZone {
+	return e.zone
+}
+
+// Types of standing zones
+const (
+	ZoneChampion    = "champion"
+	ZonePromotion   = "promotion"
+	ZoneContinental = "continental" // qualification for a continental competition
+	ZonePlayoff     = "playoff"
+	ZoneRelegation  = "relegation"
+)
+
+// A range of positions of a table with a meaning, e.g. the relegation places
+type StandingZone struct {
+	Name         string `json:"name"` // e.g. "Champions League"
+	Type         string `json:"type"`
+	FromPosition int    `json:"from_position"`
+	ToPosition   int    `json:"to_position"`
+	Color        string `json:"color,omitempty"`
+}
+
+// Contains tells if the position is in the zone
+func (z StandingZone) Contains(position int) bool {
+	return position >= z.FromPosition && position <= z.ToPosition
+}
+
+// Links every entry of the standings to its zone
+func (s *Standings) annotateZones() {
+	for i := range s.Stage {
+		stage := &s.Stage[i]
+		annotateZones(stage.Standings, stage.Zones)
+		for j := range stage.Groups {
+			group := &stage.Groups[j]
+			// Groups without zones of their own follow the zones of the stage
+			zones := group.Zones
+			if len(zones) == 0 {
+				zones = stage.Zones
+			}
+			annotateZones(group.Standings, zones)
+		}
+	}
+}
+
+func annotateZones(entries []StandingEntry, zones []StandingZone) {
+	for i := range entries {
+		entries[i].zone = nil
+		for j := range zones {
+			if zones[j].Contains(entries[i].Position) {
+				entries[i].zone = &zones[j]
+				break
+			}
+		}
+	}
 }
 
 type Standings struct {
@@ -158,6 +375,7 @@ type Standings struct {
 	Competition  Competition `json:"competition"`
 	Area         Country     `json:"area"`
 	Stage        []Stage     `json:"stage"`
+	Provenance   *Provenance `json:"-"`
 }
 
 type Stats struct {
@@ -172,15 +390,36 @@ type Stats struct {
 }
 
 type Team struct {
-	ID           int     `json:"id"`
-	Name         string  `json:"name"`
-	OfficialName string  `json:"official_name,omitempty"`
-	Code         string  `json:"code,omitempty"`
-	Type         string  `json:"type,omitempty"`
-	Gender       string  `json:"gender"`
-	City         string  `json:"city,omitempty"`
-	Country      Country `json:"country,omitempty"`
-	Logo         string  `json:"logo"`
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	OfficialName string      `json:"official_name,omitempty"`
+	Code         string      `json:"code,omitempty"`
+	Type         TeamType    `json:"type,omitempty"`
+	Gender       string      `json:"gender"`
+	City         string      `json:"city,omitempty"`
+	Country      Country     `json:"country,omitempty"`
+	Logo         string      `json:"logo"`
+	Crest        string      `json:"crest,omitempty"`
+	Colors       TeamColors  `json:"colors,omitempty"`
+	Kits         []Kit       `json:"kits,omitempty"`
+	Provenance   *Provenance `json:"-"`
+}
+
+// Colors are hex codes, e.g. "#ff0000"
+type TeamColors struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Kit returns the kit of the given type, e.g. "home" or "away"
+func (t Team) Kit(kitType string) (Kit, bool) {
+	for _, kit := range t.Kits {
+		if kit.Type == kitType {
+			return kit, true
+		}
+	}
+	return Kit{}, false
 }
 
 type TeamDetailed struct {
@@ -188,11 +427,15 @@ type TeamDetailed struct {
 	Name         string        `json:"name"`
 	OfficialName string        `json:"official_name"`
 	Code         string        `json:"code"`
-	Type         string        `json:"type"`
+	Type         TeamType      `json:"type"`
 	Gender       string        `json:"gender"`
 	City         string        `json:"city"`
 	Country      Country       `json:"country"`
 	Logo         string        `json:"logo"`
+	Crest        string        `json:"crest,omitempty"`
+	Colors       TeamColors    `json:"colors,omitempty"`
+	Kits         []Kit         `json:"kits,omitempty"`
+	Formation    string        `json:"formation,omitempty"`
 	Lineup       []SquadMember `json:"lineup"`
 	Referee      Person        `json:"referee"`
 	TVChannel    string        `json:"tv_channel"`
@@ -207,6 +450,17 @@ type Tournament struct {
 	Season      string      `json:"season"`
 	Competition Competition `json:"competition"`
 	Area        Country     `json:"area"`
+	Provenance  *Provenance `json:"-"`
+}
+
+type TopScorer struct {
+	Position  int    `json:"position"`
+	Player    Person `json:"player"`
+	Team      Team   `json:"team"`
+	Goals     int    `json:"goals"`
+	Penalties int    `json:"penalties,omitempty"`
+	Assists   int    `json:"assists,omitempty"`
+	Played    int    `json:"played,omitempty"`
 }
 
 type TVChannel struct {
@@ -217,11 +471,33 @@ type TVChannel struct {
 }
 
 type Venue struct {
-	ID      int     `json:"id"`
-	Name    string  `json:"name"`
-	City    string  `json:"city"`
-	Country Country `json:"country"`
-	Photo   string  `json:"photo"`
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	City      string   `json:"city"`
+	Country   Country  `json:"country"`
+	Photo     string   `json:"photo"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Capacity  int      `json:"capacity,omitempty"`
+	Surface   string   `json:"surface,omitempty"` // e.g. grass, artificial or hybrid
+	Opened    int      `json:"opened,omitempty"`  // year
+	Address   string   `json:"address,omitempty"`
+	// More photos of the venue, besides Photo
+	Images []string `json:"images,omitempty"`
+	// The venue's own site and its ticket office
+	Website    string `json:"website,omitempty"`
+	TicketsURL string `json:"tickets_url,omitempty"`
+}
+
+// AllImages returns Photo followed by the other images of the venue, without duplicates
+func (v Venue) AllImages() []string {
+	var images []string
+	for _, image := range append([]string{v.Photo}, v.Images...) {
+		if image != "" && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	return images
 }
 
 type Week struct {
@@ -229,3 +505,41 @@ type Week struct {
 	StartDate string `json:"start_date"`
 	EndDate   string `json:"end_date"`
 }
+
+// A VAR check or review, taken from the timeline of a detailed event
+type VARIncident struct {
+	MatchPeriod int    `json:"match_period"`
+	Minute      int    `json:"minute"`
+	MinuteExtra int    `json:"minute_extra,omitempty"`
+	Type        string `json:"type"`
+	Team        Team   `json:"team,omitempty"`
+	Player      Person `json:"player,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	// Decision on the field, before the review
+	OriginalDecision string `json:"original_decision,omitempty"`
+	FinalDecision    string `json:"final_decision"`
+	Overturned       bool   `json:"overturned"`
+}
+
+// VARIncidents returns the VAR checks and reviews of the event, in timeline order
+func (e Event) VARIncidents() []VARIncident {
+	var incidents []VARIncident
+	for _, occ := range e.Occurrence {
+		if occ.VarType == "" {
+			continue
+		}
+		incidents = append(incidents, VARIncident{
+			MatchPeriod:      occ.MatchPeriod,
+			Minute:           occ.Minute,
+			MinuteExtra:      occ.MinuteExtra,
+			Type:             occ.VarType,
+			Team:             occ.Team,
+			Player:           occ.Player,
+			Reason:           occ.VarReason,
+			OriginalDecision: occ.VarOriginal,
+			FinalDecision:    occ.VarDecision,
+			Overturned:       occ.VarOriginal != "" && occ.VarDecision != "" && occ.VarOriginal != occ.VarDecision,
+		})
+	}
+	return incidents
+}
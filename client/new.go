@@ -0,0 +1,107 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Option configures a client built with New
+type Option func(b *builder)
+
+// What the options of New set
+type builder struct {
+	config    ClientConfig
+	logger    *slog.Logger
+	observers []Observer
+}
+
+// New builds a client for the given key, configured piecemeal with options, e.g.
+//
+//	c, err := client.New(apiKey,
+//		client.WithBaseURL(server.URL),
+//		client.WithCache(client.NewMemoryCache(100)),
+//	)
+//
+// Anything not set by an option has the same default as in VSportsClient, so responses
+// are cached in Redis unless WithCache or WithConfig says otherwise
+func New(apiKey string, opts ...Option) (*VSportsClient_s, error) {
+	b := builder{config: ClientConfig{APIKey: apiKey}}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&b)
+		}
+	}
+	c, err := VSportsClient(b.config, b.logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, observer := range b.observers {
+		c.AddObserver(observer)
+	}
+	return c, nil
+}
+
+// WithConfig starts from a config, e.g. read with LoadConfig, for the settings without
+// an option of their own. It replaces what the options before it set, so pass it first
+// The key given to New is kept when the config has none
+func WithConfig(config ClientConfig) Option {
+	return func(b *builder) {
+		if config.APIKey == "" {
+			config.APIKey = b.config.APIKey
+		}
+		b.config = config
+	}
+}
+
+// WithBaseURL points the client at another deployment of the API, e.g. a staging
+// environment or an httptest.Server. More base URLs are failed over to in order
+func WithBaseURL(baseURLs ...string) Option {
+	return func(b *builder) {
+		b.config.BaseURLs = nil
+		for _, baseURL := range baseURLs {
+			b.config.BaseURLs = append(b.config.BaseURLs, strings.TrimSuffix(baseURL, "/"))
+		}
+	}
+}
+
+// WithHTTPClient makes the API requests with the given client, e.g. one with a
+// transport of its own for instrumentation. The proxy and TLS settings are ignored then
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *builder) {
+		b.config.HTTPClient = client
+	}
+}
+
+// WithCache caches the responses in the given cache instead of Redis
+func WithCache(cache Cache) Option {
+	return func(b *builder) {
+		b.config.Cache = cache
+	}
+}
+
+// WithLogger sends the logs of the client to logger, none are written by default
+func WithLogger(logger *slog.Logger) Option {
+	return func(b *builder) {
+		b.logger = logger
+	}
+}
+
+// WithObserver registers observers of the client's events, as AddObserver does, e.g.
+// one of the promobserver or otelobserver packages. Options add up, in order
+func WithObserver(observers ...Observer) Option {
+	return func(b *builder) {
+		for _, observer := range observers {
+			if observer != nil {
+				b.observers = append(b.observers, observer)
+			}
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests, DefaultUserAgent by default
+func WithUserAgent(userAgent string) Option {
+	return func(b *builder) {
+		b.config.UserAgent = userAgent
+	}
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// OAuth2Config holds the settings for the OAuth2 client credentials flow
+type OAuth2Config struct {
+	TokenURL     string   `json:"tokenUrl"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	Scopes       []string `json:"scopes"`
+}
+
+// Tokens are refreshed this long before they expire, to absorb clock skew and slow requests
+const oauth2ExpirySkew = 30 * time.Second
+
+// Lifetime assumed for tokens returned without expires_in
+const oauth2DefaultLifetime = 5 * time.Minute
+
+// KeyInvalidator is implemented by key providers whose keys can be revoked upstream
+// The client calls Invalidate when the API rejects a key with 401 Unauthorized, and
+// makes the request once more with the key the provider gives next
+type KeyInvalidator interface {
+	Invalidate(key string)
+}
+
+// OAuth2TokenSource is a KeyProvider that obtains access tokens with the client credentials grant
+// Tokens are cached until shortly before they expire, and only one token request is made
+// at a time, which the callers needing a token wait for as long as their ctx allows
+type OAuth2TokenSource struct {
+	config     OAuth2Config
+	httpClient *http.Client
+	clock      Clock
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	// The token request in flight
+	fetches singleflight.Group
+}
+
+// NewOAuth2TokenSource creates a token source using the given http client to reach the token endpoint
+// If httpClient is nil, http.DefaultClient is used
+func NewOAuth2TokenSource(config OAuth2Config, httpClient *http.Client) *OAuth2TokenSource {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &OAuth2TokenSource{config: config, httpClient: httpClient, clock: SystemClock{}}
+}
+
+// APIKey returns a valid access token, fetching a new one if needed
+func (s *OAuth2TokenSource) APIKey(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	token, expiry := s.token, s.expiry
+	s.mu.Unlock()
+	if token != "" && s.clock.Now().Before(expiry.Add(-oauth2ExpirySkew)) {
+		return token, nil
+	}
+
+	// The lock isn't held while the token endpoint answers, so a slow one only holds up
+	// the requests needing a new token, and each of them only as long as its ctx allows
+	results := s.fetches.DoChan("token", func() (any, error) {
+		fetchCtx := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			fetchCtx, cancel = context.WithDeadline(fetchCtx, deadline)
+			defer cancel()
+		}
+		token, lifetime, err := s.fetchToken(fetchCtx)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.token = token
+		s.expiry = s.clock.Now().Add(lifetime)
+		return token, nil
+	})
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
+	}
+}
+
+// Invalidate drops the cached token if it's the given one, so the next request fetches a new one
+func (s *OAuth2TokenSource) Invalidate(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == key {
+		s.token = ""
+	}
+}
+
+type oauth2TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *OAuth2TokenSource) fetchToken(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("error requesting token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", 0, fmt.Errorf("error reading token response: %w", err)
+	}
+
+	var token oauth2TokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", 0, fmt.Errorf("error decoding token response (status %s): %w", resp.Status, err)
+	}
+	if token.Error != "" {
+		return "", 0, fmt.Errorf("token request failed: %s: %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("token request failed with status %s", resp.Status)
+	}
+	if token.AccessToken == "" {
+		return "", 0, errors.New("token response has no access token")
+	}
+
+	lifetime := oauth2DefaultLifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+	return token.AccessToken, lifetime, nil
+}
@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// A token endpoint handing out "token-1", "token-2"... valid for a minute
+func newTokenServer(t *testing.T, before func()) *testAPI {
+	var issued atomic.Int32
+	return newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if before != nil {
+			before()
+		}
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 60}`, issued.Add(1))
+	})
+}
+
+func newOAuth2Client(t *testing.T, tokens, api *testAPI, clock client.Clock) *client.VSportsClient_s {
+	return newTestClient(t, client.ClientConfig{
+		Clock:       clock,
+		MaxAttempts: 1,
+		OAuth2:      &client.OAuth2Config{TokenURL: tokens.URL, ClientID: "id", ClientSecret: "secret"},
+	}, api.URL)
+}
+
+func TestOAuth2TokenCachedUntilExpiry(t *testing.T) {
+	tokens := newTokenServer(t, nil)
+	var seen []string
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id": 1}`))
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c := newOAuth2Client(t, tokens, api, clock)
+
+	for _, advance := range []time.Duration{0, 10 * time.Second, time.Minute} {
+		clock.Advance(advance)
+		if _, err := c.GetRaw(context.Background(), "teams/1", nil, client.WithNoCache()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := fmt.Sprint([]string{"Bearer token-1", "Bearer token-1", "Bearer token-2"})
+	if fmt.Sprint(seen) != want {
+		t.Errorf("got %v, want %v", seen, want)
+	}
+}
+
+func TestOAuth2RevokedTokenRetried(t *testing.T) {
+	tokens := newTokenServer(t, nil)
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		// The first token was revoked upstream
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newOAuth2Client(t, tokens, api, nil)
+
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); err != nil {
+		t.Fatalf("got %v, want the request made again with a new token", err)
+	}
+	if calls, issued := api.calls.Load(), tokens.calls.Load(); calls != 2 || issued != 2 {
+		t.Errorf("got %d calls and %d tokens, want 2 of each", calls, issued)
+	}
+}
+
+func TestOAuth2SlowTokenEndpointHonoursContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	tokens := newTokenServer(t, func() { <-release })
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newOAuth2Client(t, tokens, api, nil)
+
+	// A first request waits for a token with no deadline
+	go c.GetRaw(context.Background(), "teams/1", nil)
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetRaw(ctx, "teams/2", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %s for the token", elapsed)
+	}
+	if issued := tokens.calls.Load(); issued != 1 {
+		t.Errorf("got %d token requests, want 1 shared", issued)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Reasons of the retries reported to observers
+const (
+	RetryFailover    = "failover"
+	RetryMediaResume = "media-resume"
+	// A request failing with a server or network error, made again after the backoff
+	RetryTransient = "transient"
+	// A request the API rate limited, made again after its Retry-After
+	RetryRateLimited = "rate-limited"
+)
+
+// RequestInfo describes a request handled by the client
+type RequestInfo struct {
+	// As requested, e.g. "teams/12"
+	Endpoint string
+	// With the IDs replaced by ":id", e.g. "teams/:id", suited as a metric label
+	Route  string
+	Group  string
+	Params map[string]string
+}
+
+// RequestResult is the outcome of a request handled by the client
+type RequestResult struct {
+	// One of the AuditCache decisions
+	Cache    string
+	Upstream bool
+	// Zero when no upstream call was made
+	Status   int
+	Bytes    int
+	Duration time.Duration
+	Err      error
+}
+
+// RetryInfo describes a retry, before it's made
+type RetryInfo struct {
+	Request RequestInfo
+	// Attempt about to be made, 1 for the first retry
+	Attempt int
+	// RetryFailover, RetryMediaResume, RetryTransient or RetryRateLimited
+	Reason string
+	// Error of the previous attempt
+	Err error
+}
+
+// Observer receives the events of the client, for metrics, tracing or logging
+// Hooks are called synchronously on the request path, so they should be quick, and a
+// panicking hook is logged and ignored. Embed NopObserver to implement only some hooks
+// See the promobserver and otelobserver packages for Prometheus and OpenTelemetry
+type Observer interface {
+	// OnRequestStart may return a derived context, e.g. carrying a span, which is used
+	// for the request and passed to the other hooks of the request
+	OnRequestStart(ctx context.Context, info RequestInfo) context.Context
+	OnRequestEnd(ctx context.Context, info RequestInfo, result RequestResult)
+	OnCacheHit(ctx context.Context, info RequestInfo)
+	OnCacheMiss(ctx context.Context, info RequestInfo)
+	OnRetry(ctx context.Context, retry RetryInfo)
+	// from and to are BreakerClosed, BreakerOpen or BreakerHalfOpen
+	OnBreakerStateChange(group, from, to string)
+	// Called the first time the API reports an endpoint as deprecated
+	OnDeprecation(ctx context.Context, notice DeprecationNotice)
+}
+
+// NopObserver implements every hook of Observer by doing nothing
+type NopObserver struct{}
+
+func (NopObserver) OnRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+func (NopObserver) OnRequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {}
+func (NopObserver) OnCacheHit(ctx context.Context, info RequestInfo)                         {}
+func (NopObserver) OnCacheMiss(ctx context.Context, info RequestInfo)                        {}
+func (NopObserver) OnRetry(ctx context.Context, retry RetryInfo)                             {}
+func (NopObserver) OnBreakerStateChange(group, from, to string)                              {}
+func (NopObserver) OnDeprecation(ctx context.Context, notice DeprecationNotice)              {}
+
+// LoggingObserver logs the events of the client as structured records
+// Requests are logged at debug level, or warning when they fail, retries at info level
+// and breaker changes and deprecations at warning level
+type LoggingObserver struct {
+	// slog.Default() when nil
+	Logger *slog.Logger
+}
+
+func (o LoggingObserver) logger() *slog.Logger {
+	if o.Logger == nil {
+		return slog.Default()
+	}
+	return o.Logger
+}
+
+func (o LoggingObserver) OnRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+
+func (o LoggingObserver) OnRequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("route", info.Route),
+		slog.String("endpoint", info.Endpoint),
+		slog.String("cache", result.Cache),
+		slog.Bool("upstream", result.Upstream),
+		slog.Duration("duration", result.Duration),
+	}
+	if result.Status != 0 {
+		attrs = append(attrs, slog.Int("status", result.Status))
+	}
+	if result.Err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", result.Err.Error()))
+	}
+	o.logger().LogAttrs(ctx, level, "vsports request", attrs...)
+}
+
+func (o LoggingObserver) OnCacheHit(ctx context.Context, info RequestInfo)  {}
+func (o LoggingObserver) OnCacheMiss(ctx context.Context, info RequestInfo) {}
+
+func (o LoggingObserver) OnRetry(ctx context.Context, retry RetryInfo) {
+	o.logger().LogAttrs(ctx, slog.LevelInfo, "vsports retry",
+		slog.String("route", retry.Request.Route),
+		slog.String("reason", retry.Reason),
+		slog.Int("attempt", retry.Attempt),
+		slog.String("error", retry.Err.Error()))
+}
+
+func (o LoggingObserver) OnBreakerStateChange(group, from, to string) {
+	o.logger().LogAttrs(context.Background(), slog.LevelWarn, "vsports circuit breaker",
+		slog.String("group", group), slog.String("from", from), slog.String("to", to))
+}
+
+func (o LoggingObserver) OnDeprecation(ctx context.Context, notice DeprecationNotice) {
+	attrs := []slog.Attr{slog.String("route", notice.Route)}
+	if !notice.Sunset.IsZero() {
+		attrs = append(attrs, slog.Time("sunset", notice.Sunset))
+	}
+	if notice.Warning != "" {
+		attrs = append(attrs, slog.String("warning", notice.Warning))
+	}
+	if notice.Link != "" {
+		attrs = append(attrs, slog.String("link", notice.Link))
+	}
+	o.logger().LogAttrs(ctx, slog.LevelWarn, "vsports deprecated endpoint", attrs...)
+}
+
+// AddObserver registers an observer of the client's events
+// Observers are called in the order they were added. It must be called before the
+// client is used concurrently
+func (c *VSportsClient_s) AddObserver(observer Observer) {
+	c.observers = append(c.observers, observer)
+}
+
+func requestInfo(endpoint string, params map[string]string) RequestInfo {
+	return RequestInfo{Endpoint: endpoint, Route: normalizeEndpoint(endpoint), Group: EndpointGroup(endpoint), Params: params}
+}
+
+func (c *VSportsClient_s) observeRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, observer := range c.observers {
+		c.safeCall("observer", func() error {
+			if derived := observer.OnRequestStart(ctx, info); derived != nil {
+				ctx = derived
+			}
+			return nil
+		})
+	}
+	return ctx
+}
+
+// Calls fn on every observer, recovering from panics
+func (c *VSportsClient_s) observe(fn func(observer Observer)) {
+	for _, observer := range c.observers {
+		c.safeCall("observer", func() error {
+			fn(observer)
+			return nil
+		})
+	}
+}
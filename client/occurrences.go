@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Layouts of the update times of occurrences and media
+var modifiedLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+func parseModified(value string) (time.Time, bool) {
+	for _, layout := range modifiedLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Tells if b is at least as recent as a. Without update times, the later one wins
+func newerOrSame(a, b string) bool {
+	ta, okA := parseModified(a)
+	tb, okB := parseModified(b)
+	if !okA || !okB {
+		return true
+	}
+	return !tb.Before(ta)
+}
+
+// Identifies an occurrence across responses: its ID, or what happened when and to whom
+func occurrenceKey(occ Occurrence) string {
+	if occ.ID != 0 {
+		return fmt.Sprintf("id:%d", occ.ID)
+	}
+	return fmt.Sprintf("%d/%d+%d/%s/%d/%d", occ.MatchPeriod, occ.Minute, occ.MinuteExtra, occ.TypeCode, occ.Team.ID, occ.Player.ID)
+}
+
+// MergeOccurrences merges lists of occurrences, e.g. from successive calls, into a
+// canonical timeline without duplicates. Of two versions of an occurrence, the one
+// modified last wins, or the one of the later list when their update times aren't
+// known, and the media of both are kept. The timeline is sorted by period and minute
+func MergeOccurrences(lists ...[]Occurrence) []Occurrence {
+	index := map[string]int{}
+	var merged []Occurrence
+	for _, list := range lists {
+		for _, occ := range list {
+			key := occurrenceKey(occ)
+			i, seen := index[key]
+			if !seen {
+				index[key] = len(merged)
+				occ.Media = MergeMedia(occ.Media)
+				merged = append(merged, occ)
+				continue
+			}
+			previous := merged[i]
+			if newerOrSame(previous.Modified, occ.Modified) {
+				occ.Media = MergeMedia(previous.Media, occ.Media)
+				merged[i] = occ
+			} else {
+				merged[i].Media = MergeMedia(occ.Media, previous.Media)
+			}
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.MatchPeriod != b.MatchPeriod {
+			return a.MatchPeriod < b.MatchPeriod
+		}
+		if a.Minute != b.Minute {
+			return a.Minute < b.Minute
+		}
+		return a.MinuteExtra < b.MinuteExtra
+	})
+	return merged
+}
+
+// Identifies a medium across responses: its ID, or where it's found
+func mediaKey(media Media_s) string {
+	if media.ID == 0 {
+		return "url:" + media.URL + media.EmbedCode
+	}
+	return fmt.Sprintf("id:%d", media.ID)
+}
+
+// MergeMedia merges lists of media without duplicates, keeping the version of each
+// medium modified last, in the order they first appear
+func MergeMedia(lists ...[]Media_s) []Media_s {
+	index := map[string]int{}
+	var merged []Media_s
+	for _, list := range lists {
+		for _, media := range list {
+			key := mediaKey(media)
+			i, seen := index[key]
+			switch {
+			case !seen:
+				index[key] = len(merged)
+				merged = append(merged, media)
+			case newerOrSame(merged[i].Modified, media.Modified):
+				merged[i] = media
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Query parameters of the request options
+const (
+	paramFields  = "fields"
+	paramInclude = "include"
+	paramStatus  = "status"
+	paramSport   = "sport"
+	paramCountry = "country"
+	paramSort    = "sort"
+)
+
+// RequestOption changes a single request of the methods accepting it, e.g. WithFields
+// Options of the query, like WithFields, end up in the query string of the request, so
+// they're part of its cache key. The cache options, like WithTTL, aren't
+type RequestOption func(o *requestOptions)
+
+// What the options of a request set
+type requestOptions struct {
+	params map[string]string
+	// Filled in by list methods, see WithPageInfo
+	page *PageInfo
+	// Override the retries of the client when set, see WithRetries
+	maxAttempts  int
+	retryBackoff Backoff
+	// Skip the cache lookup but store the response, e.g. to revalidate an expired one
+	refresh bool
+	// Skip the cache altogether
+	noCache bool
+	// Overrides the cache duration of the request when set
+	ttl time.Duration
+	// Filled in with the response, see WithRawResponse
+	raw *[]byte
+	// Expired cached copy, revalidated with a conditional request, see ConditionalWindowSeconds
+	revalidate *cacheEntry
+	// Set to the validators of the response, see withPrevious
+	validators *validators
+	// Requests a batch method makes at once, see WithConcurrency
+	concurrency int
+}
+
+// Field is a top-level field of a response, for WithFields
+type Field string
+
+// Fields of the responses
+const (
+	FieldID          Field = "id"
+	FieldName        Field = "name"
+	FieldDateTime    Field = "date_time"
+	FieldStatus      Field = "status"
+	FieldMinute      Field = "minute"
+	FieldTeamA       Field = "team_A"
+	FieldTeamB       Field = "team_B"
+	FieldScoreA      Field = "total_A"
+	FieldScoreB      Field = "total_B"
+	FieldTournament  Field = "tournament"
+	FieldStage       Field = "stage"
+	FieldVenue       Field = "venue"
+	FieldCountry     Field = "country"
+	FieldLogo        Field = "logo"
+	FieldPlayers     Field = "players"
+	FieldCoach       Field = "coach"
+	FieldStartDate   Field = "start_date"
+	FieldEndDate     Field = "end_date"
+	FieldSeason      Field = "season"
+	FieldActive      Field = "active"
+	FieldMatchPeriod Field = "match_period"
+)
+
+// Include is a related resource embedded in a response, for WithInclude
+type Include string
+
+// Related resources
+const (
+	IncludeOccurrences Include = "occurrence"
+	IncludeTVChannels  Include = "tv_channel"
+	IncludePeriods     Include = "period"
+	IncludeBroadcasts  Include = "broadcasts"
+	IncludeOfficials   Include = "officials"
+	IncludeStatistics  Include = "statistics"
+)
+
+// WithFields asks the API for these fields only, to make heavy responses lighter
+// The other fields of the returned values are left empty. Responses with a field
+// selection aren't checked against the endpoint schemas, see SchemaValidation
+func WithFields(fields ...Field) RequestOption {
+	return func(o *requestOptions) {
+		addList(o.params, paramFields, fields)
+	}
+}
+
+// WithInclude asks the API to embed these related resources in the response
+func WithInclude(includes ...Include) RequestOption {
+	return func(o *requestOptions) {
+		addList(o.params, paramInclude, includes)
+	}
+}
+
+// Statuses of events, for WithStatus
+const (
+	EventStatusScheduled = "scheduled"
+	EventStatusLive      = "live"
+	EventStatusFinished  = "finished"
+	EventStatusPostponed = "postponed"
+	EventStatusCancelled = "cancelled"
+)
+
+// SortOrder is the direction of WithSort
+type SortOrder int
+
+const (
+	Ascending SortOrder = iota
+	Descending
+)
+
+// WithStatus keeps the events with one of these statuses, e.g. EventStatusLive
+func WithStatus(statuses ...string) RequestOption {
+	return func(o *requestOptions) {
+		addList(o.params, paramStatus, statuses)
+	}
+}
+
+// WithSport keeps the events, teams or tournaments of a sport, e.g. SportFutsal
+func WithSport(sport string) RequestOption {
+	return func(o *requestOptions) {
+		if sport != "" {
+			o.params[paramSport] = sport
+		}
+	}
+}
+
+// WithCountry keeps the teams or tournaments of a country, by its ISO 3166 alpha-2
+// code, e.g. "PT"
+func WithCountry(alpha2 string) RequestOption {
+	return func(o *requestOptions) {
+		if alpha2 != "" {
+			o.params[paramCountry] = strings.ToUpper(alpha2)
+		}
+	}
+}
+
+// WithSort sorts a list by a field, e.g. WithSort(FieldDateTime, Descending) for the
+// latest events first. Only the last WithSort of a request counts
+func WithSort(field Field, order SortOrder) RequestOption {
+	return func(o *requestOptions) {
+		if field == "" {
+			return
+		}
+		value := string(field)
+		if order == Descending {
+			value = "-" + value
+		}
+		o.params[paramSort] = value
+	}
+}
+
+// WithTTL caches the response for ttl instead of the cache duration of the endpoint,
+// e.g. CacheDuration. It doesn't change how long an already cached response is served
+func WithTTL(ttl time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		if ttl > 0 {
+			o.ttl = ttl
+		}
+	}
+}
+
+// WithForceRefresh fetches the response from the API even when it's cached, and caches it
+func WithForceRefresh() RequestOption {
+	return func(o *requestOptions) {
+		o.refresh = true
+	}
+}
+
+// WithNoCache neither looks the response up in the cache nor caches it
+// The stale copy of DegradedPolicy.ServeStale is still kept, and still served when the API is down
+func WithNoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// WithRawResponse sets *raw to the JSON of the response as the API sent it, before
+// decoding, e.g. for archiving, the pagination envelope of the API included. Methods making several requests at once, like the batch
+// and overview ones, leave it untouched, and the pager of Events sets it to the last page
+func WithRawResponse(raw *[]byte) RequestOption {
+	return func(o *requestOptions) {
+		o.raw = raw
+	}
+}
+
+// Drops the WithRawResponse of the options before it, for methods making several requests at once
+func withoutRawResponse() RequestOption {
+	return func(o *requestOptions) {
+		o.raw = nil
+	}
+}
+
+// The cache options of opts only, for methods passing them on to requests of other
+// endpoints, which the options of the query wouldn't apply to
+func cacheOptions(opts []RequestOption) RequestOption {
+	o := applyOptions(nil, opts)
+	return func(to *requestOptions) {
+		to.refresh, to.noCache, to.ttl = o.refresh, o.noCache, o.ttl
+	}
+}
+
+// Adds the values to the comma separated list of a parameter, sorted so the cache key
+// doesn't depend on their order
+func addList[T ~string](params map[string]string, name string, values []T) {
+	var list []string
+	if params[name] != "" {
+		list = strings.Split(params[name], ",")
+	}
+	for _, value := range values {
+		if value != "" {
+			list = append(list, string(value))
+		}
+	}
+	if len(list) == 0 {
+		return
+	}
+	slices.Sort(list)
+	params[name] = strings.Join(slices.Compact(list), ",")
+}
+
+// Applies the options to a copy of the parameters, leaving the given map untouched
+func applyOptions(params map[string]string, opts []RequestOption) requestOptions {
+	o := requestOptions{params: make(map[string]string, len(params)+len(opts))}
+	for key, value := range params {
+		o.params[key] = value
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Query parameters of the pagination options
+const (
+	paramPage   = "page"
+	paramLimit  = "limit"
+	paramOffset = "offset"
+)
+
+// TotalCountHeader is the response header with the number of items across all the pages
+// of a list. Paginated responses carrying it are cached wrapped in a page envelope,
+// {"data": [...], "total": 120}, so the count is still known on cache hits. GetRaw
+// returns that envelope as is
+const TotalCountHeader = "X-Total-Count"
+
+// PageInfo describes the page of a list returned by a method, see WithPageInfo
+type PageInfo struct {
+	// Items across all the pages, -1 when the API didn't say
+	Total int `json:"total"`
+	// As requested, zero when not set
+	Page   int `json:"page,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// HasNext tells if there are items after this page
+// It's false when the total or the page size isn't known
+func (p PageInfo) HasNext() bool {
+	if p.Total < 0 || p.Limit <= 0 {
+		return false
+	}
+	start := p.Offset
+	if p.Page > 0 {
+		start = (p.Page - 1) * p.Limit
+	}
+	return start+p.Limit < p.Total
+}
+
+// WithPage asks for a page of a list, the first one being 1, of size items
+func WithPage(page, size int) RequestOption {
+	return func(o *requestOptions) {
+		if page > 0 {
+			o.params[paramPage] = strconv.Itoa(page)
+		}
+		if size > 0 {
+			o.params[paramLimit] = strconv.Itoa(size)
+		}
+	}
+}
+
+// WithLimit asks for at most limit items of a list
+func WithLimit(limit int) RequestOption {
+	return func(o *requestOptions) {
+		if limit > 0 {
+			o.params[paramLimit] = strconv.Itoa(limit)
+		}
+	}
+}
+
+// WithOffset skips the first offset items of a list, usually along with WithLimit
+func WithOffset(offset int) RequestOption {
+	return func(o *requestOptions) {
+		if offset > 0 {
+			o.params[paramOffset] = strconv.Itoa(offset)
+		}
+	}
+}
+
+// WithPageInfo fills info with the pagination of the list returned, e.g. its total
+// Only list methods, like GetTournaments or GetEventsByDate, fill it
+func WithPageInfo(info *PageInfo) RequestOption {
+	return func(o *requestOptions) {
+		o.page = info
+	}
+}
+
+func paginated(params map[string]string) bool {
+	return params[paramPage] != "" || params[paramLimit] != "" || params[paramOffset] != ""
+}
+
+// A list along with its pagination, as cached for responses with a TotalCountHeader
+// and as the API may answer by itself
+type pageEnvelope struct {
+	Data   json.RawMessage `json:"data"`
+	Total  *int            `json:"total,omitempty"`
+	Page   int             `json:"page,omitempty"`
+	Limit  int             `json:"limit,omitempty"`
+	Offset int             `json:"offset,omitempty"`
+	// Set on the envelopes of the client, as opposed to those of the API
+	Wrapped bool `json:"wrapped,omitempty"`
+}
+
+// Wraps a page in an envelope with the total from the header, if there's a valid one
+// The page is kept byte for byte, see rawResponse
+func wrapPage(body []byte, header string) []byte {
+	total, err := strconv.Atoi(header)
+	if err != nil || total < 0 {
+		return body
+	}
+	return fmt.Appendf(nil, `{"data":%s,"total":%d,"wrapped":true}`, bytes.TrimSpace(body), total)
+}
+
+// Returns the response as the API sent it, out of the envelope of wrapPage
+func rawResponse(body []byte) []byte {
+	if envelope, ok := unwrapPage(body); ok && envelope.Wrapped {
+		return envelope.Data
+	}
+	return body
+}
+
+// Returns the envelope of a page, if the body is one
+func unwrapPage(body []byte) (pageEnvelope, bool) {
+	var envelope pageEnvelope
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return envelope, false
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Data) == 0 {
+		return envelope, false
+	}
+	return envelope, true
+}
+
+// Returns the list of a page envelope, or the body as is
+func pageData(body []byte) []byte {
+	if envelope, ok := unwrapPage(body); ok {
+		return envelope.Data
+	}
+	return body
+}
+
+// Returns the list of a response, from a page envelope or not, filling the PageInfo of the options
+func unwrapList(body []byte, opts []RequestOption) []byte {
+	o := applyOptions(nil, opts)
+	if o.page == nil {
+		return pageData(body)
+	}
+	info := PageInfo{Total: -1}
+	info.Page, _ = strconv.Atoi(o.params[paramPage])
+	info.Limit, _ = strconv.Atoi(o.params[paramLimit])
+	info.Offset, _ = strconv.Atoi(o.params[paramOffset])
+
+	if envelope, ok := unwrapPage(body); ok {
+		body = envelope.Data
+		if envelope.Total != nil {
+			info.Total = *envelope.Total
+		}
+		if envelope.Page > 0 {
+			info.Page = envelope.Page
+		}
+		if envelope.Limit > 0 {
+			info.Limit = envelope.Limit
+		}
+		if envelope.Offset > 0 {
+			info.Offset = envelope.Offset
+		}
+	}
+	*o.page = info
+	return body
+}
@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestRawResponseOfPages(t *testing.T) {
+	const list = `[ {"id": 1, "name": "Liga"} ]`
+	const envelope = `{"data": [{"id": 1, "name": "Liga"}], "total": 12, "page": 1, "limit": 1}`
+	for name, tc := range map[string]struct {
+		body, totalHeader string
+	}{
+		"total in a header":   {body: list, totalHeader: "12"},
+		"envelope of the API": {body: envelope},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+				if tc.totalHeader != "" {
+					w.Header().Set(client.TotalCountHeader, tc.totalHeader)
+				}
+				w.Write([]byte(tc.body))
+			})
+			c := newTestClient(t, client.ClientConfig{}, api.URL)
+
+			// Once from the API, once from the cache
+			for range 2 {
+				var raw []byte
+				var info client.PageInfo
+				tournaments, err := c.GetTournaments(context.Background(), client.WithPage(1, 1), client.WithPageInfo(&info), client.WithRawResponse(&raw))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(raw) != tc.body {
+					t.Errorf("got raw response %s, want %s", raw, tc.body)
+				}
+				if len(tournaments) != 1 || info.Total != 12 {
+					t.Errorf("got %d tournaments of %d, want 1 of 12", len(tournaments), info.Total)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PrefetchPriority ranks prefetch jobs by how valuable a fresh copy of their data is
+type PrefetchPriority int
+
+const (
+	// Static data such as tournaments, teams and venues
+	PriorityStatic PrefetchPriority = iota
+	// Today's fixtures and standings
+	PriorityToday
+	// Events that are being played right now
+	PriorityLive
+)
+
+// Number of priority levels, used to size the queues
+const numPrefetchPriorities = int(PriorityLive) + 1
+
+func (p PrefetchPriority) String() string {
+	switch p {
+	case PriorityStatic:
+		return "static"
+	case PriorityToday:
+		return "today"
+	case PriorityLive:
+		return "live"
+	}
+	return fmt.Sprintf("priority(%d)", int(p))
+}
+
+// DefaultPrefetchWeights is how often each priority is served relative to the others
+// With these weights, out of 10 jobs picked while all queues are busy, 6 are live, 3 are for
+// today and 1 is static data, so lower priorities are slowed down but never starved
+var DefaultPrefetchWeights = map[PrefetchPriority]int{
+	PriorityLive:   6,
+	PriorityToday:  3,
+	PriorityStatic: 1,
+}
+
+// PrefetchJob is a single refresh to be executed by the Prefetcher
+type PrefetchJob struct {
+	// Key identifies the job. Enqueueing a job with a key that is already queued
+	// doesn't add a duplicate, but raises the queued job's priority if needed
+	Key      string
+	Priority PrefetchPriority
+	Fetch    func(ctx context.Context) error
+}
+
+// Prefetcher runs refresh jobs through a weighted priority queue
+// so the request budget is spent on the most valuable data first
+type Prefetcher struct {
+	client  *VSportsClient_s
+	workers int
+
+	mu      sync.Mutex
+	queues  [numPrefetchPriorities][]PrefetchJob
+	queued  map[string]PrefetchPriority
+	weights [numPrefetchPriorities]int
+	current [numPrefetchPriorities]int
+	notify  chan struct{}
+}
+
+// NewPrefetcher creates a Prefetcher that executes jobs with the given number of workers
+func NewPrefetcher(c *VSportsClient_s, workers int) *Prefetcher {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &Prefetcher{
+		client:  c,
+		workers: workers,
+		queued:  map[string]PrefetchPriority{},
+		notify:  make(chan struct{}, 1),
+	}
+	p.SetWeights(DefaultPrefetchWeights)
+	return p
+}
+
+// SetWeights changes the relative share of each priority
+// Priorities missing from the map (or with a weight below 1) get a weight of 1
+func (p *Prefetcher) SetWeights(weights map[PrefetchPriority]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.weights {
+		p.weights[i] = 1
+		if w := weights[PrefetchPriority(i)]; w > 1 {
+			p.weights[i] = w
+		}
+		p.current[i] = 0
+	}
+}
+
+// Enqueue adds a job to the queue of its priority
+func (p *Prefetcher) Enqueue(job PrefetchJob) {
+	if job.Fetch == nil {
+		return
+	}
+	if job.Priority < PriorityStatic {
+		job.Priority = PriorityStatic
+	}
+	if job.Priority > PriorityLive {
+		job.Priority = PriorityLive
+	}
+
+	p.mu.Lock()
+	if prev, ok := p.queued[job.Key]; ok && job.Key != "" {
+		if job.Priority <= prev {
+			p.mu.Unlock()
+			return
+		}
+		p.removeLocked(prev, job.Key)
+	}
+	p.queues[job.Priority] = append(p.queues[job.Priority], job)
+	if job.Key != "" {
+		p.queued[job.Key] = job.Priority
+	}
+	p.mu.Unlock()
+
+	p.wake()
+}
+
+// Len returns the number of jobs waiting to be executed
+func (p *Prefetcher) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	total := 0
+	for _, q := range p.queues {
+		total += len(q)
+	}
+	return total
+}
+
+// Run executes queued jobs until the context is cancelled
+func (p *Prefetcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (p *Prefetcher) work(ctx context.Context) {
+	for {
+		job, ok := p.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.notify:
+				continue
+			}
+		}
+
+		// Let the other workers know there may be more work waiting
+		p.wake()
+
+		if ctx.Err() != nil {
+			return
+		}
+		p.client.profile(ctx, SubsystemPrefetch, "", func(ctx context.Context) {
+			if err := p.client.safeCall("prefetch job", func() error { return job.Fetch(ctx) }); err != nil {
+				p.client.logger.Error(fmt.Sprintf("Prefetch job %s (%s) failed: %v", job.Key, job.Priority, err))
+			} else {
+				p.client.logger.Debug(fmt.Sprintf("Prefetch job %s (%s) done", job.Key, job.Priority))
+			}
+		})
+	}
+}
+
+// Picks the next job using smooth weighted round-robin over the non-empty queues
+func (p *Prefetcher) next() (PrefetchJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best, total := -1, 0
+	for i := range p.queues {
+		if len(p.queues[i]) == 0 {
+			continue
+		}
+		p.current[i] += p.weights[i]
+		total += p.weights[i]
+		if best < 0 || p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+	if best < 0 {
+		return PrefetchJob{}, false
+	}
+	p.current[best] -= total
+
+	job := p.queues[best][0]
+	p.queues[best] = p.queues[best][1:]
+	if job.Key != "" {
+		delete(p.queued, job.Key)
+	}
+	return job, true
+}
+
+func (p *Prefetcher) removeLocked(priority PrefetchPriority, key string) {
+	q := p.queues[priority]
+	for i := range q {
+		if q[i].Key == key {
+			p.queues[priority] = append(q[:i], q[i+1:]...)
+			break
+		}
+	}
+	delete(p.queued, key)
+}
+
+func (p *Prefetcher) wake() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
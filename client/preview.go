@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// How far back GetMatchPreview looks for the form of the teams and their past meetings
+const previewHistoryDays = 365
+
+// Number of results making the form of a team
+const previewFormLength = 5
+
+// MatchPreview gathers what a match preview page shows
+type MatchPreview struct {
+	Event *Event `json:"event"`
+	Venue Venue  `json:"venue"`
+	// Last results of each team, most recent first
+	TeamAForm []Event `json:"teamAForm"`
+	TeamBForm []Event `json:"teamBForm"`
+	// Past meetings of the two teams over the last year, most recent first
+	HeadToHead []Event `json:"headToHead"`
+	// Announced lineups, nil until the API has them
+	Lineup *Lineup `json:"lineup,omitempty"`
+	// Squads of the teams, for probable lineups while Lineup is nil
+	TeamASquad *Squad `json:"teamASquad,omitempty"`
+	TeamBSquad *Squad `json:"teamBSquad,omitempty"`
+	// Standings entries of the teams, nil when the tournament has no standings
+	TeamAStanding *StandingEntry `json:"teamAStanding,omitempty"`
+	TeamBStanding *StandingEntry `json:"teamBStanding,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+// The lineups are only part of the detailed event
+type detailedEventTeams struct {
+	TeamA TeamDetailed `json:"team_A"`
+	TeamB TeamDetailed `json:"team_B"`
+}
+
+// Returns the announced lineups, nil until the API has them
+func (t detailedEventTeams) lineup() *Lineup {
+	if len(t.TeamA.Lineup) == 0 && len(t.TeamB.Lineup) == 0 {
+		return nil
+	}
+	return &Lineup{TeamALineup: t.TeamA.Lineup, TeamBLineup: t.TeamB.Lineup}
+}
+
+// GetMatchPreview assembles the preview of an event: the form of both teams, their past
+// meetings, the lineups (or the squads until lineups are announced), the venue and the
+// standings positions. The parts are fetched concurrently, and the preview is cached
+// as a whole for PreviewCacheDuration. When some parts fail, the preview holds the
+// others, isn't cached, and the error joins the failures
+// Only the cache options, like WithTTL, apply, to the preview and to its parts
+func (c *VSportsClient_s) GetMatchPreview(ctx context.Context, eventID int, opts ...RequestOption) (*MatchPreview, error) {
+	cacheKey := c.cacheKey(fmt.Sprintf("preview/%d", eventID), "")
+	o := applyOptions(nil, opts)
+	if !o.noCache && !o.refresh {
+		if cached, found, _ := c.cacheGet(ctx, cacheKey); found {
+			var preview MatchPreview
+			if err := json.Unmarshal(cached, &preview); err == nil {
+				c.logger.Debug(fmt.Sprintf("Using cached preview for event %d", eventID))
+				return &preview, nil
+			}
+		}
+	}
+
+	preview, err := c.buildMatchPreview(ctx, eventID, cacheOptions(opts))
+	if err != nil {
+		return preview, fmt.Errorf("error getting preview of event %d: %w", eventID, err)
+	}
+
+	if !o.noCache {
+		ttl := o.ttl
+		if ttl == 0 {
+			ttl = c.settings.previewCacheDuration()
+		}
+		data, err := json.Marshal(preview)
+		if err == nil {
+			err = c.cache.Set(ctx, cacheKey, data, ttl)
+		}
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("Error caching preview of event %d: %v", eventID, err))
+		}
+	}
+	return preview, nil
+}
+
+func (c *VSportsClient_s) buildMatchPreview(ctx context.Context, eventID int, cached RequestOption) (*MatchPreview, error) {
+	endpoint := fmt.Sprintf("events/%d/detailed", eventID)
+	body, err := c.request(ctx, endpoint, nil, cached)
+	if err != nil {
+		return nil, err
+	}
+	var event Event
+	if err := c.decodeResponse(endpoint, body, &event); err != nil {
+		return nil, err
+	}
+	var teams detailedEventTeams
+	// Only a part of the event, strict decoding doesn't apply
+	if err := decode(body, &teams); err != nil {
+		return nil, err
+	}
+
+	preview := &MatchPreview{Event: &event, Venue: event.Venue, Lineup: teams.lineup(), CreatedAt: c.clock.Now()}
+
+	// The history ends the day before the match, or today for matches already played
+	end := c.clock.Now().UTC()
+	if day := DateOf(event.DateTime.UTC()).Time(); !event.DateTime.IsZero() && day.Before(end) {
+		end = day
+	}
+	end = end.AddDate(0, 0, -1)
+	teamA, teamB := event.TeamA.ID, event.TeamB.ID
+
+	tasks := []func() error{
+		func() error {
+			history, err := c.eventsBetween(ctx, end.AddDate(0, 0, -previewHistoryDays), end, func(e Event) bool {
+				return e.ID != eventID && (e.TeamA.ID == teamA || e.TeamB.ID == teamA || e.TeamA.ID == teamB || e.TeamB.ID == teamB)
+			}, cached)
+			if err != nil {
+				return err
+			}
+			sortByDate(history)
+			slices.Reverse(history)
+			for _, e := range history {
+				playsA := e.TeamA.ID == teamA || e.TeamB.ID == teamA
+				playsB := e.TeamA.ID == teamB || e.TeamB.ID == teamB
+				if playsA && playsB {
+					preview.HeadToHead = append(preview.HeadToHead, e)
+				}
+				if playsA && len(preview.TeamAForm) < previewFormLength {
+					preview.TeamAForm = append(preview.TeamAForm, e)
+				}
+				if playsB && len(preview.TeamBForm) < previewFormLength {
+					preview.TeamBForm = append(preview.TeamBForm, e)
+				}
+			}
+			return nil
+		},
+		func() error {
+			standings, err := c.GetStandingsByTournament(ctx, event.Tournament.ID, cached)
+			if errors.Is(err, ErrNotFound) {
+				// Cups and friendlies have no table
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			preview.TeamAStanding = standingOf(standings, teamA)
+			preview.TeamBStanding = standingOf(standings, teamB)
+			return nil
+		},
+	}
+	if preview.Lineup == nil {
+		tasks = append(tasks,
+			func() (err error) {
+				preview.TeamASquad, err = c.GetSquad(ctx, teamA, cached)
+				return err
+			},
+			func() (err error) {
+				preview.TeamBSquad, err = c.GetSquad(ctx, teamB, cached)
+				return err
+			},
+		)
+	}
+
+	// A partial preview is still worth showing, the event itself was found
+	return preview, runConcurrently(aggregateConcurrency, tasks...)
+}
+
+// Finds the standings entry of a team, in the first stage listing it
+func standingOf(standings *Standings, teamID int) *StandingEntry {
+	for _, stage := range standings.Stage {
+		for i := range stage.Standings {
+			if stage.Standings[i].Team.ID == teamID {
+				return &stage.Standings[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"regexp"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// pprof label keys set on goroutines doing vsports work
+const (
+	ProfileLabelSubsystem = "vsports_subsystem"
+	ProfileLabelEndpoint  = "vsports_endpoint"
+)
+
+// Subsystems reported in the vsports_subsystem label
+const (
+	SubsystemAPI      = "api"
+	SubsystemPrefetch = "prefetch"
+)
+
+// ProfilingHook is called when the client starts a unit of work, with the context already
+// carrying the pprof labels. The returned function (if not nil) is called when the work is done
+type ProfilingHook func(ctx context.Context, subsystem, endpoint string) func()
+
+// TraceRegionHook is a ProfilingHook that records each unit of work as a runtime/trace region,
+// so it shows up in `go tool trace` next to the pprof labels
+func TraceRegionHook(ctx context.Context, subsystem, endpoint string) func() {
+	name := "vsports." + subsystem
+	if endpoint != "" {
+		name += " " + endpoint
+	}
+	return trace.StartRegion(ctx, name).End
+}
+
+// SetProfilingHook sets a hook called around every request and background job
+// Passing nil removes the hook. It must be called before the client is used concurrently
+func (c *VSportsClient_s) SetProfilingHook(hook ProfilingHook) {
+	c.profilingHook = hook
+}
+
+// Runs fn with the vsports pprof labels applied to the current goroutine
+// When nested, the outermost subsystem is kept, so requests made by the prefetcher
+// are attributed to the prefetcher and not to plain API calls
+func (c *VSportsClient_s) profile(ctx context.Context, subsystem, endpoint string, fn func(ctx context.Context)) {
+	if !c.profilingLabels && c.profilingHook == nil {
+		fn(ctx)
+		return
+	}
+
+	if c.profilingLabels {
+		labels := []string{}
+		if _, ok := pprof.Label(ctx, ProfileLabelSubsystem); !ok {
+			labels = append(labels, ProfileLabelSubsystem, subsystem)
+		}
+		if endpoint != "" {
+			labels = append(labels, ProfileLabelEndpoint, normalizeEndpoint(endpoint))
+		}
+		pprof.Do(ctx, pprof.Labels(labels...), func(ctx context.Context) {
+			c.runProfilingHook(ctx, subsystem, endpoint, fn)
+		})
+		return
+	}
+	c.runProfilingHook(ctx, subsystem, endpoint, fn)
+}
+
+func (c *VSportsClient_s) runProfilingHook(ctx context.Context, subsystem, endpoint string, fn func(ctx context.Context)) {
+	if c.profilingHook != nil {
+		if done := c.profilingHook(ctx, subsystem, normalizeEndpoint(endpoint)); done != nil {
+			defer done()
+		}
+	}
+	fn(ctx)
+}
+
+var numericSegment = regexp.MustCompile(`(^|/)\d+(/|$)`)
+
+// Replaces the IDs in an endpoint path with a placeholder
+// e.g. "squads/12/by/tournament/3" becomes "squads/:id/by/tournament/:id"
+// This keeps the number of distinct values low when endpoints are used as labels
+func normalizeEndpoint(endpoint string) string {
+	// Run twice since consecutive IDs share the separating slash
+	for i := 0; i < 2; i++ {
+		endpoint = numericSegment.ReplaceAllString(endpoint, "$1:id$2")
+	}
+	return endpoint
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Provider is the set of core read operations a sports data source must offer
+// VSportsClient_s implements it, so another data source implementing it can be
+// used as a fallback with FallbackProvider. Data sources may ignore the request
+// options they don't support
+type Provider interface {
+	// Name identifies the data source in provenance records and logs
+	Name() string
+
+	GetTournaments(ctx context.Context, opts ...RequestOption) ([]Tournament, error)
+	GetTournamentById(ctx context.Context, tournamentID int, opts ...RequestOption) (*Tournament, error)
+	GetTeamById(ctx context.Context, teamID int, opts ...RequestOption) (*Team, error)
+	GetTeamsByTournamentId(ctx context.Context, tournamentID int, opts ...RequestOption) ([]Team, error)
+	GetEventsByDate(ctx context.Context, startDate, endDate Date, opts ...RequestOption) ([]Event, error)
+	GetEventById(ctx context.Context, eventID int, opts ...RequestOption) (*Event, error)
+	GetSquad(ctx context.Context, teamID int, opts ...RequestOption) (*Squad, error)
+	GetStandingsByTournament(ctx context.Context, tournamentID int, opts ...RequestOption) (*Standings, error)
+}
+
+var _ Provider = (*VSportsClient_s)(nil)
+
+// Provenance records which data source a model came from
+type Provenance struct {
+	Source    string    `json:"source"`
+	Fallback  bool      `json:"fallback"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// Why the primary source wasn't used, when Fallback is true
+	PrimaryError string `json:"primaryError,omitempty"`
+}
+
+// ProviderName is the name VSportsClient_s reports as a Provider
+const ProviderName = "vsports"
+
+// Name identifies the VSports client as a Provider
+func (c *VSportsClient_s) Name() string {
+	return ProviderName
+}
+
+// FallbackProvider serves every call from the primary provider, falling back to the
+// secondary one when the primary fails. The models returned carry a Provenance telling
+// which of the two answered
+type FallbackProvider struct {
+	Primary   Provider
+	Secondary Provider
+	Logger    *slog.Logger
+}
+
+// NewFallbackProvider creates a provider falling back from primary to secondary
+func NewFallbackProvider(primary, secondary Provider, logger *slog.Logger) *FallbackProvider {
+	if logger == nil {
+		logger = slog.New(&noopLogger{})
+	}
+	return &FallbackProvider{Primary: primary, Secondary: secondary, Logger: logger}
+}
+
+func (p *FallbackProvider) Name() string {
+	if p.Secondary == nil {
+		return p.Primary.Name()
+	}
+	return fmt.Sprintf("%s+%s", p.Primary.Name(), p.Secondary.Name())
+}
+
+// Calls the primary provider and then the secondary one if needed, stamping the result with its provenance
+func withFallback[T any](p *FallbackProvider, operation string, call func(Provider) (T, error), stamp func(T, *Provenance)) (T, error) {
+	result, err := call(p.Primary)
+	if err == nil {
+		stamp(result, &Provenance{Source: p.Primary.Name(), FetchedAt: time.Now()})
+		return result, nil
+	}
+	if p.Secondary == nil {
+		return result, err
+	}
+
+	p.Logger.Warn(fmt.Sprintf("%s failed on %s, falling back to %s: %v", operation, p.Primary.Name(), p.Secondary.Name(), err))
+	result, fallbackErr := call(p.Secondary)
+	if fallbackErr != nil {
+		var zero T
+		return zero, errors.Join(
+			fmt.Errorf("%s failed on %s: %w", operation, p.Primary.Name(), err),
+			fmt.Errorf("%s failed on fallback %s: %w", operation, p.Secondary.Name(), fallbackErr),
+		)
+	}
+	stamp(result, &Provenance{Source: p.Secondary.Name(), Fallback: true, FetchedAt: time.Now(), PrimaryError: err.Error()})
+	return result, nil
+}
+
+func (p *FallbackProvider) GetTournaments(ctx context.Context, opts ...RequestOption) ([]Tournament, error) {
+	return withFallback(p, "GetTournaments", func(pr Provider) ([]Tournament, error) {
+		return pr.GetTournaments(ctx, opts...)
+	}, func(tournaments []Tournament, prov *Provenance) {
+		for i := range tournaments {
+			tournaments[i].Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetTournamentById(ctx context.Context, tournamentID int, opts ...RequestOption) (*Tournament, error) {
+	return withFallback(p, "GetTournamentById", func(pr Provider) (*Tournament, error) {
+		return pr.GetTournamentById(ctx, tournamentID, opts...)
+	}, func(tournament *Tournament, prov *Provenance) {
+		if tournament != nil {
+			tournament.Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetTeamById(ctx context.Context, teamID int, opts ...RequestOption) (*Team, error) {
+	return withFallback(p, "GetTeamById", func(pr Provider) (*Team, error) {
+		return pr.GetTeamById(ctx, teamID, opts...)
+	}, func(team *Team, prov *Provenance) {
+		if team != nil {
+			team.Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetTeamsByTournamentId(ctx context.Context, tournamentID int, opts ...RequestOption) ([]Team, error) {
+	return withFallback(p, "GetTeamsByTournamentId", func(pr Provider) ([]Team, error) {
+		return pr.GetTeamsByTournamentId(ctx, tournamentID, opts...)
+	}, func(teams []Team, prov *Provenance) {
+		for i := range teams {
+			teams[i].Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetEventsByDate(ctx context.Context, startDate, endDate Date, opts ...RequestOption) ([]Event, error) {
+	return withFallback(p, "GetEventsByDate", func(pr Provider) ([]Event, error) {
+		return pr.GetEventsByDate(ctx, startDate, endDate, opts...)
+	}, func(events []Event, prov *Provenance) {
+		for i := range events {
+			events[i].Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetEventById(ctx context.Context, eventID int, opts ...RequestOption) (*Event, error) {
+	return withFallback(p, "GetEventById", func(pr Provider) (*Event, error) {
+		return pr.GetEventById(ctx, eventID, opts...)
+	}, func(event *Event, prov *Provenance) {
+		if event != nil {
+			event.Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetSquad(ctx context.Context, teamID int, opts ...RequestOption) (*Squad, error) {
+	return withFallback(p, "GetSquad", func(pr Provider) (*Squad, error) {
+		return pr.GetSquad(ctx, teamID, opts...)
+	}, func(squad *Squad, prov *Provenance) {
+		if squad != nil {
+			squad.Provenance = prov
+		}
+	})
+}
+
+func (p *FallbackProvider) GetStandingsByTournament(ctx context.Context, tournamentID int, opts ...RequestOption) (*Standings, error) {
+	return withFallback(p, "GetStandingsByTournament", func(pr Provider) (*Standings, error) {
+		return pr.GetStandingsByTournament(ctx, tournamentID, opts...)
+	}, func(standings *Standings, prov *Provenance) {
+		if standings != nil {
+			standings.Provenance = prov
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitState is the rate-limit information reported by the API in response headers
+type RateLimitState struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Exhausted tells if no requests are left until the limit resets
+func (s RateLimitState) Exhausted(now time.Time) bool {
+	return !s.UpdatedAt.IsZero() && s.Remaining <= 0 && now.Before(s.Reset)
+}
+
+// Interval tells how far apart requests should be to make the remaining requests last
+// until the limit resets. It's zero when the state doesn't say, and the time left until
+// the reset when nothing is left
+func (s RateLimitState) Interval(now time.Time) time.Duration {
+	if s.UpdatedAt.IsZero() || s.Reset.IsZero() || s.Remaining < 0 {
+		return 0
+	}
+	left := s.Reset.Sub(now)
+	if left <= 0 {
+		return 0
+	}
+	if s.Remaining == 0 {
+		return left
+	}
+	return left / time.Duration(s.Remaining)
+}
+
+// Header names used by the provider, with the common alternatives
+var (
+	rateLimitLimitHeaders     = []string{"X-RateLimit-Limit", "RateLimit-Limit", "X-Rate-Limit-Limit"}
+	rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining", "X-Rate-Limit-Remaining"}
+	rateLimitResetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset", "X-Rate-Limit-Reset"}
+)
+
+// Reset values above this are unix timestamps, below it they are seconds from now
+const rateLimitEpochThreshold = 1_000_000_000
+
+// Extracts the rate-limit state from the response headers
+// The boolean is false when the response carries no rate-limit information
+func parseRateLimit(resp *http.Response, now time.Time) (RateLimitState, bool) {
+	state := RateLimitState{UpdatedAt: now, Limit: -1, Remaining: -1}
+	found := false
+
+	if v, ok := headerInt(resp.Header, rateLimitLimitHeaders); ok {
+		state.Limit = int(v)
+		found = true
+	}
+	if v, ok := headerInt(resp.Header, rateLimitRemainingHeaders); ok {
+		state.Remaining = int(v)
+		found = true
+	}
+	if v, ok := headerInt(resp.Header, rateLimitResetHeaders); ok {
+		if v > rateLimitEpochThreshold {
+			state.Reset = time.Unix(v, 0)
+		} else {
+			state.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		found = true
+	}
+
+	// A 429 means nothing is left, whatever the headers say
+	if resp.StatusCode == http.StatusTooManyRequests {
+		state.Remaining = 0
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			state.Reset = now.Add(d)
+		}
+		found = true
+	}
+
+	return state, found
+}
+
+// Parses a Retry-After header, which holds either seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func headerInt(h http.Header, names []string) (int64, bool) {
+	for _, name := range names {
+		if v := strings.TrimSpace(h.Get(name)); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// The last rate-limit state reported by the API to a client
+type rateLimitTracker struct {
+	mu    sync.Mutex
+	state RateLimitState
+}
+
+func (t *rateLimitTracker) observe(state RateLimitState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state = state
+}
+
+func (t *rateLimitTracker) get() (RateLimitState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state, !t.state.UpdatedAt.IsZero()
+}
+
+// RateLimitStatus returns the rate-limit state reported by the last API response that carried one
+// The boolean is false until such a response is received
+// With a pool of keys, it's the state of the last key used, see KeyStates for all of them
+func (c *VSportsClient_s) RateLimitStatus() (RateLimitState, bool) {
+	return c.rateLimit.get()
+}
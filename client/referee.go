@@ -0,0 +1,79 @@
+package client
+
+import "context"
+
+// RefereeMatch is what a referee gave in one match
+type RefereeMatch struct {
+	EventID   int    `json:"eventId"`
+	Date      string `json:"date"`
+	TeamA     string `json:"teamA"`
+	TeamB     string `json:"teamB"`
+	Yellow    int    `json:"yellow"`
+	Red       int    `json:"red"`
+	Penalties int    `json:"penalties"`
+}
+
+// RefereeStats sums up the cards and penalties given by a referee
+// Second yellows count as red cards. Penalty shootouts are not counted
+type RefereeStats struct {
+	Referee           Person         `json:"referee"`
+	Matches           int            `json:"matches"`
+	Yellow            int            `json:"yellow"`
+	Red               int            `json:"red"`
+	Penalties         int            `json:"penalties"`
+	YellowPerMatch    float64        `json:"yellowPerMatch"`
+	RedPerMatch       float64        `json:"redPerMatch"`
+	PenaltiesPerMatch float64        `json:"penaltiesPerMatch"`
+	PerMatch          []RefereeMatch `json:"perMatch"`
+}
+
+// AggregateRefereeStats sums up the matches refereed by the given referee among the
+// events, which must be detailed events so they carry their officials and timeline
+func AggregateRefereeStats(refereeID int, events []Event) RefereeStats {
+	events = append([]Event(nil), events...)
+	sortByDate(events)
+
+	stats := RefereeStats{Referee: Person{ID: refereeID}}
+	for _, event := range events {
+		referee := event.Referee()
+		if referee == nil || referee.ID != refereeID {
+			continue
+		}
+		stats.Referee = referee.Person
+
+		match := RefereeMatch{EventID: event.ID, Date: event.DateUTC, TeamA: event.TeamA.Name, TeamB: event.TeamB.Name}
+		for _, occ := range event.Occurrence {
+			switch occ.TypeCode {
+			case OccurrenceYellowCard:
+				match.Yellow++
+			case OccurrenceSecondYellowCard, OccurrenceRedCard:
+				match.Red++
+			case OccurrencePenaltyGoal, OccurrencePenaltyMissed:
+				match.Penalties++
+			}
+		}
+		stats.Matches++
+		stats.Yellow += match.Yellow
+		stats.Red += match.Red
+		stats.Penalties += match.Penalties
+		stats.PerMatch = append(stats.PerMatch, match)
+	}
+
+	if stats.Matches > 0 {
+		stats.YellowPerMatch = float64(stats.Yellow) / float64(stats.Matches)
+		stats.RedPerMatch = float64(stats.Red) / float64(stats.Matches)
+		stats.PenaltiesPerMatch = float64(stats.Penalties) / float64(stats.Matches)
+	}
+	return stats
+}
+
+// GetRefereeStats sums up the matches of a tournament refereed by the given referee
+// between two dates, with AggregateRefereeStats
+func (c *VSportsClient_s) GetRefereeStats(ctx context.Context, refereeID, tournamentID int, startDate, endDate Date, opts ...RequestOption) (*RefereeStats, error) {
+	events, err := c.tournamentEventsDetailed(ctx, tournamentID, startDate, endDate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	stats := AggregateRefereeStats(refereeID, events)
+	return &stats, nil
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The settings of a client that can be changed while it's running
+type settings struct {
+	mu            sync.RWMutex
+	timeout       time.Duration
+	cacheDuration time.Duration
+	maxMediaBytes int64
+	previewTTL    time.Duration
+	historicalTTL time.Duration
+	watchInterval time.Duration
+	watchIdle     time.Duration
+}
+
+func newSettings(config ClientConfig) *settings {
+	s := &settings{}
+	s.apply(config)
+	return s
+}
+
+func (s *settings) apply(config ClientConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	s.cacheDuration = time.Duration(config.CacheDuration) * time.Second
+	s.maxMediaBytes = config.MaxMediaBytes
+	s.previewTTL = time.Duration(config.PreviewCacheDuration) * time.Second
+	s.historicalTTL = time.Duration(config.HistoricalCacheDuration) * time.Second
+	s.watchInterval = time.Duration(config.WatchIntervalSeconds) * time.Second
+	s.watchIdle = time.Duration(config.WatchIdleIntervalSeconds) * time.Second
+}
+
+func (s *settings) get() (timeout, cacheDuration time.Duration, maxMediaBytes int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.timeout, s.cacheDuration, s.maxMediaBytes
+}
+
+func (s *settings) previewCacheDuration() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.previewTTL
+}
+
+func (s *settings) historicalCacheDuration() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.historicalTTL
+}
+
+// Returns the polling intervals of WatchEvent, while the event is live and before
+func (s *settings) watchIntervals() (interval, idle time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	interval, idle = s.watchInterval, s.watchIdle
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	if idle <= 0 {
+		idle = DefaultWatchIdleInterval
+	}
+	return interval, idle
+}
+
+// Level below every slog level, used to let everything through when no log level is configured
+const logEverything = slog.LevelDebug - 4
+
+// Parses the log level of the config
+// An empty level lets everything through, leaving the filtering to the logger given to the client
+func parseLogLevel(level string) (slog.Level, error) {
+	if level == "" {
+		return logEverything, nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return 0, fmt.Errorf("invalid logLevel %q", level)
+	}
+	return l, nil
+}
+
+// Wraps the handler of the logger given to the client, so its level can be changed at runtime
+type levelHandler struct {
+	level   *slog.LevelVar
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
+
+// UpdateConfig applies a new config to the running client, without dropping the cache
+// or the connections. Only these settings are applied:
+//
+//   - timeoutSeconds, maxMediaBytes and the cache durations
+//   - watchIntervalSeconds and watchIdleIntervalSeconds, from the next poll of the watches
+//   - logLevel
+//   - apiKey, when set and the client uses a single key rather than a pool, a key file
+//     or OAuth2
+//
+// The other settings (Redis, base URLs, proxy, TLS...) need a new client to change
+func (c *VSportsClient_s) UpdateConfig(config ClientConfig) error {
+	config = config.WithDefaults()
+	if err := config.validate(false); err != nil {
+		return err
+	}
+	level, err := parseLogLevel(config.LogLevel)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	c.settings.apply(config)
+	c.logLevel.Set(level)
+	// A key pool, a key file or OAuth2 keep providing the keys, the key only replaces a key
+	if config.APIKey != "" && !c.keys.replaceStatic(StaticKey(config.APIKey)) {
+		c.logger.Debug("Not applying the apiKey of the new config, the keys come from a provider")
+	}
+
+	c.logger.Info(fmt.Sprintf("Applied new config: timeout %ds, cache duration %ds, watch intervals %ds/%ds, log level %q",
+		config.TimeoutSeconds, config.CacheDuration, config.WatchIntervalSeconds, config.WatchIdleIntervalSeconds, config.LogLevel))
+	return nil
+}
+
+// WatchConfig checks the config file every interval and applies it with UpdateConfig
+// when it changes. It blocks until the context is cancelled
+// Invalid files are logged and ignored, so the client keeps running with the last good config
+func (c *VSportsClient_s) WatchConfig(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	var lastModified time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastModified = info.ModTime()
+	}
+
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("Error checking config file %s: %v", path, err))
+			continue
+		}
+		if info.ModTime().Equal(lastModified) {
+			continue
+		}
+		lastModified = info.ModTime()
+
+		config, err := LoadConfig(path)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("Ignoring invalid config file %s: %v", path, err))
+			continue
+		}
+		if err := c.UpdateConfig(config); err != nil {
+			c.logger.Error(fmt.Sprintf("Error applying config file %s: %v", path, err))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultMaxAttempts is the number of attempts of a request when ClientConfig.MaxAttempts isn't set
+const DefaultMaxAttempts = 3
+
+// Longest Retry-After the client waits for. When the API asks for more, the request fails
+// right away with the error matching ErrRateLimited
+const maxRetryAfter = 30 * time.Second
+
+// WithRetries overrides the attempts and the backoff of the client for one request,
+// e.g. more patience for a batch job. An attempts of 1 disables the retries, and a nil
+// backoff keeps the one of the client
+func WithRetries(attempts int, backoff Backoff) RequestOption {
+	return func(o *requestOptions) {
+		if attempts > 0 {
+			o.maxAttempts = attempts
+		}
+		if backoff != nil {
+			o.retryBackoff = backoff
+		}
+	}
+}
+
+// Tells if a request failing with err may succeed if made again: the API was rate
+// limiting, failed with a server error or a malformed response, or couldn't be reached
+func retryable(err error) bool {
+	// Out of budget already, see RetryBudget, MaxCallsPerHour and RateLimit
+	if errors.Is(err, ErrRetryBudgetExhausted) || errors.Is(err, ErrBudgetExceeded) || errors.Is(err, ErrRateBudgetExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerError) || errors.Is(err, ErrMalformedResponse) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Returns how long the API asked to wait before retrying, zero when it didn't say
+func retryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// Makes the request, failing over to the other base URLs, and retries it while it fails
+// with a retryable error, waiting for the backoff or the Retry-After of the API
+// Every retry takes from the retry budget and the call budget
+func (c *VSportsClient_s) fetchWithRetries(ctx context.Context, endpoint string, o requestOptions, record *AuditRecord) (fetchedResponse, error) {
+	attempts := o.maxAttempts
+	if attempts == 0 {
+		attempts = c.maxAttempts
+	}
+	backoff := o.retryBackoff
+	if backoff == nil {
+		backoff = c.retryBackoff
+	}
+
+	c.retries.request(c.clock.Now())
+	var lastErr error
+	var delay time.Duration
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			reason := RetryTransient
+			delay = backoff.Delay(attempt, delay)
+			if wait := retryAfter(lastErr); wait > 0 {
+				if wait > maxRetryAfter {
+					c.logger.Warn(fmt.Sprintf("Not retrying %s, the API asked to wait %s", endpoint, wait))
+					return fetchedResponse{}, lastErr
+				}
+				reason = RetryRateLimited
+				delay = wait
+			}
+			// Don't wait for an attempt that can't finish in time anyway
+			if !deadlineAllows(ctx, delay) {
+				c.logger.Debug(fmt.Sprintf("Not retrying %s, the deadline is too close", endpoint))
+				return fetchedResponse{}, lastErr
+			}
+			if !c.retries.allowRetry(c.clock.Now()) {
+				c.logger.Warn(fmt.Sprintf("Retry budget exhausted, not retrying %s", endpoint))
+				return fetchedResponse{}, fmt.Errorf("%w while retrying: %w", ErrRetryBudgetExhausted, lastErr)
+			}
+			if !c.budget.take(c.clock.Now()) {
+				return fetchedResponse{}, fmt.Errorf("%w while retrying: %w", ErrBudgetExceeded, lastErr)
+			}
+			c.logger.Debug(fmt.Sprintf("Retrying %s in %s (attempt %d): %v", endpoint, delay.Round(time.Millisecond), attempt+1, lastErr))
+			c.observe(func(obs Observer) {
+				obs.OnRetry(ctx, RetryInfo{Request: requestInfo(endpoint, o.params), Attempt: attempt, Reason: reason, Err: lastErr})
+			})
+			if err := sleepContext(ctx, c.clock, delay); err != nil {
+				return fetchedResponse{}, lastErr
+			}
+		}
+
+		fetched, err := c.fetchWithFailover(ctx, endpoint, o.params, o.conditional(), record)
+		if err == nil {
+			return fetched, nil
+		}
+		if !retryable(err) || ctx.Err() != nil {
+			return fetchedResponse{}, attemptError(ctx, err, lastErr)
+		}
+		lastErr = err
+	}
+
+	if attempts > 1 {
+		c.logger.Error(fmt.Sprintf("Giving up on %s after %d attempts: %v", endpoint, attempts, lastErr))
+	}
+	return fetchedResponse{}, lastErr
+}
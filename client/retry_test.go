@@ -0,0 +1,107 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestRetriesServerErrors(t *testing.T) {
+	var api *testAPI
+	api = newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if api.calls.Load() < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  3,
+		RetryBackoff: client.ConstantBackoff(time.Millisecond),
+	}, api.URL)
+
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if calls := api.calls.Load(); calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetriesGiveUpWithLastError(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  3,
+		RetryBackoff: client.ConstantBackoff(time.Millisecond),
+	}, api.URL)
+
+	var apiErr *client.APIError
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("got %v, want the 502 of the API", err)
+	}
+	if calls := api.calls.Load(); calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  3,
+		RetryBackoff: client.ConstantBackoff(time.Millisecond),
+	}, api.URL)
+
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetryWaitsForRetryAfter(t *testing.T) {
+	var api *testAPI
+	api = newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if api.calls.Load() == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts:  2,
+		RetryBackoff: client.ConstantBackoff(time.Millisecond),
+	}, api.URL)
+
+	start := time.Now()
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want the second the API asked for", elapsed)
+	}
+}
+
+func TestRetryAfterTooLongFailsRightAway(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	c := newTestClient(t, client.ClientConfig{MaxAttempts: 3}, api.URL)
+
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("got %v, want ErrRateLimited", err)
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
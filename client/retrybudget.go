@@ -0,0 +1,118 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults of the retry budget
+const (
+	DefaultRetryBudgetRatio         = 0.2
+	DefaultRetryBudgetMinRetries    = 3
+	DefaultRetryBudgetWindowSeconds = 10
+)
+
+// ErrRetryBudgetExhausted is returned along with the last error when a request could
+// have been retried, but the retry budget of the client was used up
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget caps the retries of the client to a share of its requests, so an outage
+// doesn't turn into a retry storm. It covers every second attempt the client makes:
+// retrying a request, failing over to another base URL, resuming a media download...
+// Clients of a ClientManager share one budget
+type RetryBudget struct {
+	// Retries allowed per request made, 0.2 allows one retry every 5 requests
+	// DefaultRetryBudgetRatio when nil, so zero only leaves MinRetries
+	Ratio *float64 `json:"ratio"`
+	// Retries always allowed per window, so a quiet client can still retry
+	// DefaultRetryBudgetMinRetries when nil, so zero only leaves the Ratio
+	MinRetries *int `json:"minRetries"`
+	// Length of the windows requests and retries are counted in
+	WindowSeconds int `json:"windowSeconds"`
+}
+
+// RetryBudgetStatus is the usage of the retry budget in the current window
+type RetryBudgetStatus struct {
+	Requests int `json:"requests"`
+	Retries  int `json:"retries"`
+	// Retries still allowed in the window, as of now
+	Available int       `json:"available"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Counts requests and retries in fixed windows aligned to the clock
+type retryBudget struct {
+	ratio      float64
+	minRetries int
+	window     time.Duration
+
+	mu       sync.Mutex
+	start    time.Time
+	requests int
+	retries  int
+}
+
+func newRetryBudget(config RetryBudget) *retryBudget {
+	b := &retryBudget{
+		ratio:      DefaultRetryBudgetRatio,
+		minRetries: DefaultRetryBudgetMinRetries,
+		window:     time.Duration(config.WindowSeconds) * time.Second,
+	}
+	if config.Ratio != nil {
+		b.ratio = *config.Ratio
+	}
+	if config.MinRetries != nil {
+		b.minRetries = *config.MinRetries
+	}
+	return b
+}
+
+func (b *retryBudget) rollLocked(now time.Time) {
+	if start := now.UTC().Truncate(b.window); !start.Equal(b.start) {
+		b.start = start
+		b.requests = 0
+		b.retries = 0
+	}
+}
+
+func (b *retryBudget) availableLocked() int {
+	return max(b.minRetries+int(b.ratio*float64(b.requests))-b.retries, 0)
+}
+
+// Counts a first attempt
+func (b *retryBudget) request(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	b.requests++
+}
+
+// Takes a retry from the budget, returning false if there's none left
+func (b *retryBudget) allowRetry(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	if b.availableLocked() == 0 {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+func (b *retryBudget) status(now time.Time) RetryBudgetStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	return RetryBudgetStatus{
+		Requests:  b.requests,
+		Retries:   b.retries,
+		Available: b.availableLocked(),
+		Reset:     b.start.Add(b.window),
+	}
+}
+
+// RetryBudgetStatus returns how much of the retry budget is used in the current window
+func (c *VSportsClient_s) RetryBudgetStatus() RetryBudgetStatus {
+	return c.retries.status(c.clock.Now())
+}
@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestRetryBudgetExhausted(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ratio, minRetries := 0.0, 1
+	c := newTestClient(t, client.ClientConfig{
+		Clock:        client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC)),
+		MaxAttempts:  3,
+		RetryBackoff: client.ConstantBackoff(0),
+		RetryBudget:  client.RetryBudget{Ratio: &ratio, MinRetries: &minRetries, WindowSeconds: 10},
+	}, api.URL)
+	ctx := context.Background()
+
+	// The one retry of the window goes to the first request
+	if _, err := c.GetRaw(ctx, "teams/1", nil); !errors.Is(err, client.ErrRetryBudgetExhausted) || !errors.Is(err, client.ErrServerError) {
+		t.Fatalf("got %v, want ErrRetryBudgetExhausted along with the server error", err)
+	}
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	if _, err := c.GetRaw(ctx, "teams/2", nil); !errors.Is(err, client.ErrRetryBudgetExhausted) {
+		t.Fatalf("got %v, want ErrRetryBudgetExhausted", err)
+	}
+	if calls := api.calls.Load(); calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+	if status := c.RetryBudgetStatus(); status.Requests != 2 || status.Retries != 1 || status.Available != 0 {
+		t.Errorf("got %+v", status)
+	}
+}
+
+func TestRetryBudgetKeepsExplicitZero(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ratio, minRetries := 0.0, 0
+	c := newTestClient(t, client.ClientConfig{
+		MaxAttempts: 3,
+		RetryBudget: client.RetryBudget{Ratio: &ratio, MinRetries: &minRetries},
+	}, api.URL)
+
+	if _, err := c.GetRaw(context.Background(), "teams/1", nil); !errors.Is(err, client.ErrRetryBudgetExhausted) {
+		t.Fatalf("got %v, want ErrRetryBudgetExhausted", err)
+	}
+	if calls := api.calls.Load(); calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
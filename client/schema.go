@@ -0,0 +1,331 @@
+package client
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The JSON Schemas of the endpoints, one file per endpoint named after its normalized
+// path with slashes replaced by underscores, e.g. teams_id.json. Shared definitions
+// live in models.json and are referenced with "models.json#/$defs/<name>"
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// ErrNoSchema is returned by ValidateResponse for endpoints without a schema
+var ErrNoSchema = errors.New("no schema for endpoint")
+
+// SchemaValidation configures the checking of API responses against the JSON Schemas
+// shipped with the client. Violations are logged, counted in SchemaStats and reported to
+// the OnSchemaViolation function. Responses are served all the same
+type SchemaValidation struct {
+	// Fraction of the upstream responses checked, from 0 (never) to 1 (every response)
+	SampleRate float64 `json:"sampleRate"`
+}
+
+// SchemaViolation is a part of a response that doesn't match the schema of its endpoint
+type SchemaViolation struct {
+	// Location of the offending value, e.g. "[3].team_A.id"
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v SchemaViolation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// SchemaReport lists the violations found in a response
+type SchemaReport struct {
+	Endpoint   string            `json:"endpoint"`
+	Violations []SchemaViolation `json:"violations"`
+}
+
+// EndpointSchemaStats counts the responses of an endpoint checked against its schema
+type EndpointSchemaStats struct {
+	Validated int `json:"validated"`
+	Invalid   int `json:"invalid"`
+}
+
+// A subset of JSON Schema, enough for the API payloads
+type jsonSchema struct {
+	Ref        string                 `json:"$ref"`
+	Defs       map[string]*jsonSchema `json:"$defs"`
+	Type       schemaTypes            `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Items      *jsonSchema            `json:"items"`
+	Enum       []any                  `json:"enum"`
+	Minimum    *float64               `json:"minimum"`
+	Pattern    string                 `json:"pattern"`
+
+	resolved *jsonSchema
+	pattern  *regexp.Regexp
+}
+
+// "type" is either a single type or a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// The schemas are parsed on first use
+var loadSchemas = sync.OnceValues(func() (map[string]*jsonSchema, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+	schemas := map[string]*jsonSchema{}
+	for _, entry := range entries {
+		data, err := schemaFiles.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		var schema jsonSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("error decoding schema %s: %w", entry.Name(), err)
+		}
+		schemas[strings.TrimSuffix(entry.Name(), ".json")] = &schema
+	}
+	models := schemas["models"]
+	if models == nil {
+		return nil, errors.New("missing schema models.json")
+	}
+	delete(schemas, "models")
+	for name, def := range models.Defs {
+		if err := def.compile(models.Defs); err != nil {
+			return nil, fmt.Errorf("error in definition %s: %w", name, err)
+		}
+	}
+	for name, schema := range schemas {
+		if err := schema.compile(models.Defs); err != nil {
+			return nil, fmt.Errorf("error in schema %s: %w", name, err)
+		}
+	}
+	return schemas, nil
+})
+
+// Resolves the references and compiles the patterns of the schema
+func (s *jsonSchema) compile(defs map[string]*jsonSchema) error {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref[strings.Index(s.Ref, "#")+1:], "/$defs/")
+		target, ok := defs[name]
+		if !ok {
+			return fmt.Errorf("unknown reference %q", s.Ref)
+		}
+		s.resolved = target
+		return nil
+	}
+	if s.Pattern != "" && s.pattern == nil {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = pattern
+	}
+	for _, property := range s.Properties {
+		if err := property.compile(defs); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile(defs)
+	}
+	return nil
+}
+
+// Records the violations of v, a value decoded with UseNumber, found at path
+func (s *jsonSchema) validate(path string, v any, violations *[]SchemaViolation) {
+	if s.resolved != nil {
+		s.resolved.validate(path, v, violations)
+		return
+	}
+	if len(s.Type) > 0 && !slices.Contains(s.Type, schemaTypeOf(v)) && !(schemaTypeOf(v) == "integer" && slices.Contains(s.Type, "number")) {
+		*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.Type, " or "), schemaTypeOf(v))})
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf("unexpected value %v", v)})
+	}
+	switch v := v.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil && s.Minimum != nil && f < *s.Minimum {
+			*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf("%v is below the minimum of %v", v, *s.Minimum)})
+		}
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf("%q doesn't match %s", v, s.Pattern)})
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf("missing required field %s", name)})
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if value, ok := v[name]; ok {
+				s.Properties[name].validate(joinSchemaPath(path, name), value, violations)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+			}
+		}
+	}
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func schemaTypeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+// ValidateResponse checks a response of the endpoint, e.g. "teams/12", against the
+// schema shipped for it. It returns the violations found, none when the response is
+// valid, and ErrNoSchema for endpoints without a schema
+func ValidateResponse(endpoint string, body []byte) ([]SchemaViolation, error) {
+	schemas, err := loadSchemas()
+	if err != nil {
+		return nil, err
+	}
+	name := strings.NewReplacer("/", "_", ":", "").Replace(normalizeEndpoint(strings.Trim(endpoint, "/")))
+	schema, ok := schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoSchema, endpoint)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return []SchemaViolation{{Message: fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+	var violations []SchemaViolation
+	schema.validate("", doc, &violations)
+	return violations, nil
+}
+
+// Counts the responses checked against the schemas
+type schemaTracker struct {
+	sampleRate float64
+
+	mu          sync.Mutex
+	stats       map[string]EndpointSchemaStats
+	onViolation func(SchemaReport)
+}
+
+func newSchemaTracker(config SchemaValidation) *schemaTracker {
+	return &schemaTracker{sampleRate: config.SampleRate, stats: map[string]EndpointSchemaStats{}}
+}
+
+// Checks a sample of the upstream responses against their schema
+func (c *VSportsClient_s) validateSample(endpoint string, body []byte) {
+	if c.schemas.sampleRate <= 0 || rand.Float64() >= c.schemas.sampleRate {
+		return
+	}
+	violations, err := ValidateResponse(endpoint, body)
+	if errors.Is(err, ErrNoSchema) {
+		return
+	}
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("Error loading response schemas: %v", err))
+		return
+	}
+
+	key := normalizeEndpoint(endpoint)
+	c.schemas.mu.Lock()
+	stats := c.schemas.stats[key]
+	stats.Validated++
+	if len(violations) > 0 {
+		stats.Invalid++
+	}
+	c.schemas.stats[key] = stats
+	onViolation := c.schemas.onViolation
+	c.schemas.mu.Unlock()
+
+	if len(violations) == 0 {
+		return
+	}
+	messages := make([]string, 0, min(len(violations), 5))
+	for _, violation := range violations[:cap(messages)] {
+		messages = append(messages, violation.String())
+	}
+	c.logger.Warn(fmt.Sprintf("Response of %s doesn't match its schema (%d violations): %s", endpoint, len(violations), strings.Join(messages, "; ")))
+	if onViolation != nil {
+		c.safeCall("schema violation", func() error {
+			onViolation(SchemaReport{Endpoint: endpoint, Violations: violations})
+			return nil
+		})
+	}
+}
+
+// SchemaStats returns how many responses of each endpoint were checked against their
+// schema, and how many of them didn't match. Endpoints are grouped like in UsageStats
+func (c *VSportsClient_s) SchemaStats() map[string]EndpointSchemaStats {
+	c.schemas.mu.Lock()
+	defer c.schemas.mu.Unlock()
+	stats := make(map[string]EndpointSchemaStats, len(c.schemas.stats))
+	for endpoint, s := range c.schemas.stats {
+		stats[endpoint] = s
+	}
+	return stats
+}
+
+// OnSchemaViolation registers a function called with the violations of every checked
+// response that doesn't match its schema, e.g. to raise an alert
+func (c *VSportsClient_s) OnSchemaViolation(fn func(SchemaReport)) {
+	c.schemas.mu.Lock()
+	defer c.schemas.mu.Unlock()
+	c.schemas.onViolation = fn
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// The season methods fetch the data of a given season, for archive pages
+// Seasons are given as the API names them, e.g. "2023/2024" or "2023", and an empty
+// season means the current one. Past seasons don't change anymore, so they are cached
+// for HistoricalCacheDuration instead of CacheDuration
+
+var seasonYear = regexp.MustCompile(`\d{4}`)
+
+// Tells if the season is over for good, i.e. it ended in a previous year
+// Seasons whose end year can't be told apart are treated as current
+func (c *VSportsClient_s) isPastSeason(season string) bool {
+	years := seasonYear.FindAllString(season, -1)
+	if len(years) == 0 {
+		return false
+	}
+	endYear, _ := strconv.Atoi(years[len(years)-1])
+	return endYear < c.clock.Now().Year()
+}
+
+// Adds the season to the params and picks the cache duration matching it
+func (c *VSportsClient_s) seasonParams(season string, params map[string]string) (map[string]string, time.Duration) {
+	if season == "" {
+		return params, 0
+	}
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["season"] = season
+	if c.isPastSeason(season) {
+		return params, c.settings.historicalCacheDuration()
+	}
+	return params, 0
+}
+
+func (c *VSportsClient_s) GetStandingsBySeason(ctx context.Context, tournamentID int, season string, opts ...RequestOption) (*Standings, error) {
+	params, ttl := c.seasonParams(season, nil)
+	endpoint := fmt.Sprintf("standings/by/tournament/%d", tournamentID)
+	body, err := c.requestTTL(ctx, endpoint, params, ttl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var standings Standings
+	if err := c.decodeResponse(endpoint, body, &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
+
+func (c *VSportsClient_s) GetEventsBySeason(ctx context.Context, tournamentID int, season string, opts ...RequestOption) ([]Event, error) {
+	params, ttl := c.seasonParams(season, map[string]string{"tournament_id": strconv.Itoa(tournamentID)})
+	body, err := c.requestTTL(ctx, "events", params, ttl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if err := c.decodeList("events", body, &events, opts); err != nil {
+		return nil, err
+	}
+	c.enrich(ctx, events)
+	return events, nil
+}
+
+func (c *VSportsClient_s) GetSquadBySeason(ctx context.Context, teamID int, season string, opts ...RequestOption) (*Squad, error) {
+	params, ttl := c.seasonParams(season, nil)
+	endpoint := fmt.Sprintf("squads/%d", teamID)
+	body, err := c.requestTTL(ctx, endpoint, params, ttl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var squad Squad
+	err = c.decodeResponse(endpoint, body, &squad)
+	return &squad, err
+}
+
+// GetTopScorers returns the top scorers of a tournament for a season, the current one when empty
+func (c *VSportsClient_s) GetTopScorers(ctx context.Context, tournamentID int, season string, opts ...RequestOption) ([]TopScorer, error) {
+	params, ttl := c.seasonParams(season, nil)
+	endpoint := fmt.Sprintf("topscorers/by/tournament/%d", tournamentID)
+	body, err := c.requestTTL(ctx, endpoint, params, ttl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var scorers []TopScorer
+	err = c.decodeResponse(endpoint, body, &scorers)
+	return scorers, err
+}
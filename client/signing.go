@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Signer adds a signature to a request right before it is sent
+// It runs after all other headers (Authorization included) have been set
+type Signer interface {
+	Sign(req *http.Request) error
+}
+
+// SignerFunc adapts a plain function to the Signer interface
+type SignerFunc func(req *http.Request) error
+
+func (f SignerFunc) Sign(req *http.Request) error { return f(req) }
+
+// Default header names used by HMACSigner
+const (
+	DefaultSignatureHeader = "X-Signature"
+	DefaultTimestampHeader = "X-Timestamp"
+)
+
+// HMACSigner signs requests with an HMAC over the timestamp and the request path
+// The signed message is "<unix timestamp>\n<path and query>", and the hex encoded
+// signature and the timestamp are sent in their own headers
+type HMACSigner struct {
+	Secret []byte
+	// Defaults to DefaultSignatureHeader and DefaultTimestampHeader
+	SignatureHeader string
+	TimestampHeader string
+	// Defaults to SHA-256
+	Hash func() hash.Hash
+	// Source of the timestamps, defaults to the Clock of the client it's given to
+	Clock Clock
+}
+
+func (s HMACSigner) Sign(req *http.Request) error {
+	if len(s.Secret) == 0 {
+		return errors.New("HMAC signer has no secret")
+	}
+
+	signatureHeader, timestampHeader, hashFunc := s.SignatureHeader, s.TimestampHeader, s.Hash
+	if signatureHeader == "" {
+		signatureHeader = DefaultSignatureHeader
+	}
+	if timestampHeader == "" {
+		timestampHeader = DefaultTimestampHeader
+	}
+	if hashFunc == nil {
+		hashFunc = sha256.New
+	}
+
+	var now time.Time
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	} else {
+		now = time.Now()
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(hashFunc, s.Secret)
+	mac.Write([]byte(timestamp + "\n" + req.URL.RequestURI()))
+
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// SetSigner sets the signer applied to every API request
+// Passing nil disables signing. It must be called before the client is used concurrently
+func (c *VSportsClient_s) SetSigner(signer Signer) {
+	c.signer = signerWithClock(signer, c.clock)
+}
+
+// Gives an HMACSigner without a Clock the one of the client
+// A copy, so the caller's signer is left as it is
+func signerWithClock(signer Signer, clock Clock) Signer {
+	switch s := signer.(type) {
+	case HMACSigner:
+		if s.Clock == nil {
+			s.Clock = clock
+		}
+		return s
+	case *HMACSigner:
+		if s != nil && s.Clock == nil {
+			copied := *s
+			copied.Clock = clock
+			return copied
+		}
+	}
+	return signer
+}
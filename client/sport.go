@@ -0,0 +1,113 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Sports covered by the API
+const (
+	SportFootball   = "football"
+	SportFutsal     = "futsal"
+	SportBasketball = "basketball"
+	SportHandball   = "handball"
+	SportVolleyball = "volleyball"
+	SportHockey     = "roller_hockey"
+)
+
+// ErrNoStatistics is returned when an event has no statistics, e.g. because it isn't a detailed event
+var ErrNoStatistics = errors.New("event has no statistics")
+
+// SportOf returns the sport of an event, from the event itself or from its competition
+// Events that don't say are football, the only sport of the API's early feeds
+func SportOf(event Event) string {
+	if event.Sport != "" {
+		return event.Sport
+	}
+	if event.Tournament.Competition.Sport != "" {
+		return event.Tournament.Competition.Sport
+	}
+	return SportFootball
+}
+
+// MatchStatistics holds a set of statistics for both teams of an event
+type MatchStatistics[T any] struct {
+	TeamA T `json:"team_A"`
+	TeamB T `json:"team_B"`
+}
+
+type FootballStatistics struct {
+	Possession    int `json:"possession"` // percentage
+	Shots         int `json:"shots"`
+	ShotsOnTarget int `json:"shots_on_target"`
+	Corners       int `json:"corners"`
+	Fouls         int `json:"fouls"`
+	Offsides      int `json:"offsides"`
+	YellowCards   int `json:"yellow_cards"`
+	RedCards      int `json:"red_cards"`
+}
+
+type BasketballStatistics struct {
+	FieldGoalsMade      int `json:"field_goals_made"`
+	FieldGoalsAttempted int `json:"field_goals_attempted"`
+	ThreesMade          int `json:"three_pointers_made"`
+	ThreesAttempted     int `json:"three_pointers_attempted"`
+	FreeThrowsMade      int `json:"free_throws_made"`
+	FreeThrowsAttempted int `json:"free_throws_attempted"`
+	Rebounds            int `json:"rebounds"`
+	OffensiveRebounds   int `json:"offensive_rebounds"`
+	Assists             int `json:"assists"`
+	Steals              int `json:"steals"`
+	Blocks              int `json:"blocks"`
+	Turnovers           int `json:"turnovers"`
+	Fouls               int `json:"fouls"`
+}
+
+type HandballStatistics struct {
+	Shots                int `json:"shots"`
+	Saves                int `json:"saves"`
+	SevenMeterGoals      int `json:"seven_meter_goals"`
+	SevenMeterAttempts   int `json:"seven_meter_attempts"`
+	FastBreakGoals       int `json:"fast_break_goals"`
+	TechnicalFaults      int `json:"technical_faults"`
+	TwoMinuteSuspensions int `json:"two_minute_suspensions"`
+	YellowCards          int `json:"yellow_cards"`
+	RedCards             int `json:"red_cards"`
+	Timeouts             int `json:"timeouts"`
+}
+
+// Decodes the statistics of an event, checking it's of the expected sport
+func decodeStatistics[T any](event Event, sports ...string) (*MatchStatistics[T], error) {
+	sport := SportOf(event)
+	matches := false
+	for _, s := range sports {
+		matches = matches || s == sport
+	}
+	if !matches {
+		return nil, fmt.Errorf("event %d is a %s event, not %s", event.ID, sport, sports[0])
+	}
+	if len(event.Statistics) == 0 || string(event.Statistics) == "null" {
+		return nil, ErrNoStatistics
+	}
+	var stats MatchStatistics[T]
+	if err := json.Unmarshal(event.Statistics, &stats); err != nil {
+		return nil, fmt.Errorf("error decoding statistics of event %d: %w", event.ID, err)
+	}
+	return &stats, nil
+}
+
+// FootballStatistics decodes the statistics of a football or futsal event
+func (e Event) FootballStatistics() (*MatchStatistics[FootballStatistics], error) {
+	return decodeStatistics[FootballStatistics](e, SportFootball, SportFutsal)
+}
+
+// BasketballStatistics decodes the statistics of a basketball event
+func (e Event) BasketballStatistics() (*MatchStatistics[BasketballStatistics], error) {
+	return decodeStatistics[BasketballStatistics](e, SportBasketball)
+}
+
+// HandballStatistics decodes the statistics of a handball event
+func (e Event) HandballStatistics() (*MatchStatistics[HandballStatistics], error) {
+	return decodeStatistics[HandballStatistics](e, SportHandball)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A cached response along with when it was fetched, how long it's fresh for and its validators
+// Entries are stored as "@<fetched at, unix ms>,<ttl ms>[\t<etag>\t<last modified>]\n<body>"
+// Entries stored without that header, e.g. by an older version of the client, have a zero FetchedAt
+type cacheEntry struct {
+	body       []byte
+	fetchedAt  time.Time
+	ttl        time.Duration
+	validators validators
+}
+
+func encodeCacheEntry(body []byte, fetchedAt time.Time, ttl time.Duration, v validators) []byte {
+	header := fmt.Sprintf("@%d,%d%s\n", fetchedAt.UnixMilli(), ttl.Milliseconds(), v.encode())
+	entry := make([]byte, 0, len(header)+len(body))
+	return append(append(entry, header...), body...)
+}
+
+func decodeCacheEntry(value []byte) cacheEntry {
+	header, body, ok := bytes.Cut(value, []byte("\n"))
+	if !ok || !bytes.HasPrefix(header, []byte("@")) {
+		return cacheEntry{body: value}
+	}
+	header, encodedValidators, _ := bytes.Cut(header, []byte("\t"))
+	fetchedAt, ttl, ok := bytes.Cut(header[1:], []byte(","))
+	if !ok {
+		return cacheEntry{body: value}
+	}
+	fetchedMs, err := strconv.ParseInt(string(fetchedAt), 10, 64)
+	if err != nil {
+		return cacheEntry{body: value}
+	}
+	ttlMs, err := strconv.ParseInt(string(ttl), 10, 64)
+	if err != nil {
+		return cacheEntry{body: value}
+	}
+	return cacheEntry{
+		body:       body,
+		fetchedAt:  time.UnixMilli(fetchedMs),
+		ttl:        time.Duration(ttlMs) * time.Millisecond,
+		validators: decodeValidators(string(encodedValidators)),
+	}
+}
+
+// Tells if the entry is past its freshness. Entries without a fetch time never are
+func (e cacheEntry) expired(now time.Time) bool {
+	return !e.fetchedAt.IsZero() && !now.Before(e.fetchedAt.Add(e.ttl))
+}
+
+// The keys being revalidated in the background
+type keySet struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func newKeySet() *keySet {
+	return &keySet{keys: map[string]bool{}}
+}
+
+// Adds the key, telling if it wasn't there yet
+func (s *keySet) add(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys[key] {
+		return false
+	}
+	s.keys[key] = true
+	return true
+}
+
+func (s *keySet) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
+
+// Refreshes an expired cache entry in the background, unless it's being refreshed already
+// The refresh outlives the request that triggered it, within the timeout of the client
+func (c *VSportsClient_s) revalidate(ctx context.Context, cacheKey, endpoint string, o requestOptions, ttl time.Duration) {
+	if !c.revalidating.add(cacheKey) {
+		return
+	}
+	o.refresh = true
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer c.revalidating.remove(cacheKey)
+		if _, err := c.doRequest(ctx, endpoint, o, ttl); err != nil {
+			c.logger.Warn(fmt.Sprintf("Error revalidating %s, the expired response stays cached: %v", cacheKey, err))
+		}
+	}()
+}
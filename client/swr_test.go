@@ -0,0 +1,57 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestExpiredServedWhileRevalidating(t *testing.T) {
+	release := make(chan struct{})
+	var api *testAPI
+	api = newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		call := api.calls.Load()
+		if call > 1 {
+			<-release
+		}
+		fmt.Fprintf(w, `{"id": 1, "version": %d}`, call)
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c := newTestClient(t, client.ClientConfig{Clock: clock, StaleWhileRevalidateSeconds: 60}, api.URL)
+	get := func() string {
+		t.Helper()
+		body, err := c.GetRaw(context.Background(), "teams/1", nil, client.WithTTL(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	first := get()
+	clock.Advance(90 * time.Second)
+
+	// The API is stuck, but the expired response is served right away
+	if body := get(); body != first {
+		t.Fatalf("got %s, want the expired %s", body, first)
+	}
+	if body := get(); body != first {
+		t.Fatalf("got %s, want the expired %s", body, first)
+	}
+	close(release)
+
+	timeout := time.Now().Add(5 * time.Second)
+	for get() == first {
+		if time.Now().After(timeout) {
+			t.Fatal("response never revalidated")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// A single revalidation for all the requests of the expired response
+	if calls := api.calls.Load(); calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+}
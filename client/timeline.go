@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"sort"
+)
+
+// Occurrence type codes of the event timeline
+const (
+	OccurrenceGoal             = "goal"
+	OccurrenceOwnGoal          = "own_goal"
+	OccurrencePenaltyGoal      = "penalty_goal"
+	OccurrencePenaltyMissed    = "penalty_missed"
+	OccurrenceYellowCard       = "yellow_card"
+	OccurrenceSecondYellowCard = "second_yellow_card"
+	OccurrenceRedCard          = "red_card"
+	OccurrenceSubstitution     = "substitution"
+)
+
+// Goal is a goal of an event, taken from its timeline
+type Goal struct {
+	MatchPeriod int    `json:"match_period"`
+	Minute      int    `json:"minute"`
+	MinuteExtra int    `json:"minute_extra,omitempty"`
+	Type        string `json:"type"` // OccurrenceGoal, OccurrenceOwnGoal or OccurrencePenaltyGoal
+	// Team credited with the goal. For own goals, the team of the scorer
+	Team   Team    `json:"team"`
+	Scorer Person  `json:"scorer"`
+	Assist *Person `json:"assist,omitempty"`
+	// Score after the goal, when the API gives it
+	TeamAScore *int `json:"team_A_score,omitempty"`
+	TeamBScore *int `json:"team_B_score,omitempty"`
+}
+
+// IsGoal tells if the occurrence is a goal of any type
+func (o Occurrence) IsGoal() bool {
+	switch o.TypeCode {
+	case OccurrenceGoal, OccurrenceOwnGoal, OccurrencePenaltyGoal:
+		return true
+	}
+	return false
+}
+
+// Goals returns the goals of the event in the order they were scored
+// Only detailed events carry their timeline, see GetGoalsByEvent
+func (e Event) Goals() []Goal {
+	var goals []Goal
+	for _, occ := range e.Occurrence {
+		if !occ.IsGoal() {
+			continue
+		}
+		goal := Goal{
+			MatchPeriod: occ.MatchPeriod,
+			Minute:      occ.Minute,
+			MinuteExtra: occ.MinuteExtra,
+			Type:        occ.TypeCode,
+			Team:        occ.Team,
+			Scorer:      occ.Player,
+			TeamAScore:  occ.TeamAScore,
+			TeamBScore:  occ.TeamBScore,
+		}
+		if occ.AssistPlayer.ID != 0 {
+			assist := occ.AssistPlayer
+			goal.Assist = &assist
+		}
+		goals = append(goals, goal)
+	}
+	sort.SliceStable(goals, func(i, j int) bool {
+		a, b := goals[i], goals[j]
+		if a.MatchPeriod != b.MatchPeriod {
+			return a.MatchPeriod < b.MatchPeriod
+		}
+		if a.Minute != b.Minute {
+			return a.Minute < b.Minute
+		}
+		return a.MinuteExtra < b.MinuteExtra
+	})
+	return goals
+}
+
+// GetGoalsByEvent returns the goals of an event in the order they were scored
+func (c *VSportsClient_s) GetGoalsByEvent(ctx context.Context, eventID int, opts ...RequestOption) ([]Goal, error) {
+	event, err := c.GetEventDetailed(ctx, eventID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return event.Goals(), nil
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Builds the transport used for API requests
+// Without an explicit proxy, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables are honored. http, https and socks5 proxy URLs are supported
+func newTransport(config ClientConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	tlsConfig, err := buildTLSConfig(config.TLSConfig, config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxyUrl: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxyUrl: unsupported scheme %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxyUrl: missing host in %q", raw)
+	}
+	return proxyURL, nil
+}
+
+// TLSOptions describes a TLS setup in config files
+// For anything not covered here, set a *tls.Config directly in the config instead
+type TLSOptions struct {
+	// PEM bundle of CAs trusted in addition to the system roots
+	CAFile string `json:"caFile"`
+	// Client certificate and key for mutual TLS
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// Lowest TLS version accepted: "1.2" or "1.3"
+	MinVersion         string `json:"minVersion"`
+	ServerName         string `json:"serverName"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+// Builds the tls.Config for the options
+// An explicit tls.Config takes precedence over the options, and nil means the Go defaults
+func buildTLSConfig(explicit *tls.Config, options *TLSOptions) (*tls.Config, error) {
+	if explicit != nil {
+		return explicit.Clone(), nil
+	}
+	if options == nil {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName:         options.ServerName,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	switch options.MinVersion {
+	case "", "1.2":
+		config.MinVersion = tls.VersionTLS12
+	case "1.3":
+		config.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("invalid TLS minVersion %q, must be 1.2 or 1.3", options.MinVersion)
+	}
+
+	if options.CAFile != "" {
+		pem, err := os.ReadFile(options.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading TLS CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", options.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if options.CertFile != "" || options.KeyFile != "" {
+		if options.CertFile == "" || options.KeyFile == "" {
+			return nil, errors.New("TLS certFile and keyFile must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultUsageWindow is the length of the windows upstream calls are counted in
+const DefaultUsageWindow = time.Hour
+
+// DefaultQuotaWarningThresholds are the fractions of the quota that trigger a warning once used
+var DefaultQuotaWarningThresholds = []float64{0.8, 0.9, 0.95}
+
+// Quota headers returned by the API
+const (
+	quotaLimitHeader     = "X-Quota-Limit"
+	quotaRemainingHeader = "X-Quota-Remaining"
+	quotaResetHeader     = "X-Quota-Reset"
+)
+
+// EndpointUsage counts the upstream calls made to one endpoint
+// Endpoints are grouped by path, with IDs replaced by ":id"
+type EndpointUsage struct {
+	Calls  int `json:"calls"`
+	Errors int `json:"errors"`
+}
+
+// UsageWindow holds the upstream calls made during one time window
+type UsageWindow struct {
+	Start     time.Time                `json:"start"`
+	End       time.Time                `json:"end"`
+	Calls     int                      `json:"calls"`
+	Endpoints map[string]EndpointUsage `json:"endpoints"`
+}
+
+// QuotaState is the contract quota as last reported by the API
+type QuotaState struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Used returns the fraction of the quota already used, or -1 if unknown
+func (q QuotaState) Used() float64 {
+	if q.Limit <= 0 || q.Remaining < 0 {
+		return -1
+	}
+	return float64(q.Limit-q.Remaining) / float64(q.Limit)
+}
+
+// UsageStats is a snapshot of the upstream usage of a client
+// Cache hits are not counted, as they don't cost anything
+type UsageStats struct {
+	Current  UsageWindow  `json:"current"`
+	Previous *UsageWindow `json:"previous,omitempty"`
+	// Calls made since the client was created
+	TotalCalls int         `json:"totalCalls"`
+	Quota      *QuotaState `json:"quota,omitempty"`
+}
+
+// QuotaWarning is emitted once per quota period for each threshold crossed
+type QuotaWarning struct {
+	Threshold float64    `json:"threshold"`
+	Quota     QuotaState `json:"quota"`
+}
+
+// Tracks the upstream calls of a client
+type usageTracker struct {
+	window     time.Duration
+	thresholds []float64
+
+	mu         sync.Mutex
+	current    UsageWindow
+	previous   *UsageWindow
+	totalCalls int
+	quota      *QuotaState
+	warned     map[float64]bool
+	onWarning  func(QuotaWarning)
+}
+
+func newUsageTracker(window time.Duration, thresholds []float64) *usageTracker {
+	if window <= 0 {
+		window = DefaultUsageWindow
+	}
+	if thresholds == nil {
+		thresholds = DefaultQuotaWarningThresholds
+	}
+	thresholds = slices.Clone(thresholds)
+	slices.Sort(thresholds)
+	return &usageTracker{
+		window:     window,
+		thresholds: thresholds,
+		warned:     map[float64]bool{},
+	}
+}
+
+// Moves to a new window if the current one is over
+func (u *usageTracker) rotateLocked(now time.Time) {
+	if u.current.Endpoints != nil && now.Before(u.current.End) {
+		return
+	}
+	if u.current.Endpoints != nil {
+		previous := u.current
+		// Only keep the previous window if it's the one right before the new one
+		if now.Sub(previous.End) < u.window {
+			u.previous = &previous
+		} else {
+			u.previous = nil
+		}
+	}
+	start := now.Truncate(u.window)
+	u.current = UsageWindow{Start: start, End: start.Add(u.window), Endpoints: map[string]EndpointUsage{}}
+}
+
+// Records an upstream call, and the quota reported in its response if any
+// It returns the quota thresholds crossed by this call
+func (u *usageTracker) record(endpoint string, resp *http.Response, failed bool, now time.Time) []QuotaWarning {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.rotateLocked(now)
+	key := normalizeEndpoint(endpoint)
+	usage := u.current.Endpoints[key]
+	usage.Calls++
+	if failed {
+		usage.Errors++
+	}
+	u.current.Endpoints[key] = usage
+	u.current.Calls++
+	u.totalCalls++
+
+	if resp == nil {
+		return nil
+	}
+	quota, ok := parseQuota(resp, now)
+	if !ok {
+		return nil
+	}
+
+	// A new quota period starts when the reset moves forward or the remaining calls go up
+	if u.quota != nil && (quota.Reset.After(u.quota.Reset) || quota.Remaining > u.quota.Remaining) {
+		clear(u.warned)
+	}
+	u.quota = &quota
+
+	var warnings []QuotaWarning
+	used := quota.Used()
+	for _, threshold := range u.thresholds {
+		if used >= threshold && !u.warned[threshold] {
+			u.warned[threshold] = true
+			warnings = append(warnings, QuotaWarning{Threshold: threshold, Quota: quota})
+		}
+	}
+	return warnings
+}
+
+func (u *usageTracker) stats(now time.Time) UsageStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.rotateLocked(now)
+	stats := UsageStats{
+		Current:    copyUsageWindow(u.current),
+		TotalCalls: u.totalCalls,
+	}
+	if u.previous != nil {
+		previous := copyUsageWindow(*u.previous)
+		stats.Previous = &previous
+	}
+	if u.quota != nil {
+		quota := *u.quota
+		stats.Quota = &quota
+	}
+	return stats
+}
+
+func copyUsageWindow(w UsageWindow) UsageWindow {
+	w.Endpoints = maps.Clone(w.Endpoints)
+	return w
+}
+
+func parseQuota(resp *http.Response, now time.Time) (QuotaState, bool) {
+	limit, hasLimit := headerInt(resp.Header, []string{quotaLimitHeader})
+	remaining, hasRemaining := headerInt(resp.Header, []string{quotaRemainingHeader})
+	if !hasLimit && !hasRemaining {
+		return QuotaState{}, false
+	}
+
+	quota := QuotaState{Limit: -1, Remaining: -1, UpdatedAt: now}
+	if hasLimit {
+		quota.Limit = int(limit)
+	}
+	if hasRemaining {
+		quota.Remaining = int(remaining)
+	}
+	if reset, ok := headerInt(resp.Header, []string{quotaResetHeader}); ok {
+		if reset > rateLimitEpochThreshold {
+			quota.Reset = time.Unix(reset, 0)
+		} else {
+			quota.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return quota, true
+}
+
+// Records an upstream call and warns about the quota thresholds it crossed
+func (c *VSportsClient_s) recordUsage(endpoint string, resp *http.Response, failed bool) {
+	warnings := c.usage.record(endpoint, resp, failed, c.clock.Now())
+	for _, warning := range warnings {
+		c.logger.Warn(fmt.Sprintf("VSports API quota is %.0f%% used (%d of %d calls left)",
+			warning.Threshold*100, warning.Quota.Remaining, warning.Quota.Limit))
+
+		c.usage.mu.Lock()
+		onWarning := c.usage.onWarning
+		c.usage.mu.Unlock()
+		if onWarning != nil {
+			c.safeCall("quota warning", func() error {
+				onWarning(warning)
+				return nil
+			})
+		}
+	}
+}
+
+// UsageStats returns the upstream calls made by the client per endpoint,
+// for the current and previous time windows, and the last quota reported by the API
+func (c *VSportsClient_s) UsageStats() UsageStats {
+	return c.usage.stats(c.clock.Now())
+}
+
+// OnQuotaWarning registers a function called when the used quota crosses one of the
+// warning thresholds. Each threshold is reported once per quota period
+func (c *VSportsClient_s) OnQuotaWarning(fn func(QuotaWarning)) {
+	c.usage.mu.Lock()
+	defer c.usage.mu.Unlock()
+	c.usage.onWarning = fn
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultWarmConcurrency is how many requests a Warmer makes at once when WarmerOptions.Concurrency isn't set
+const DefaultWarmConcurrency = 4
+
+// WarmResource is a kind of data fetched by a Warmer
+type WarmResource string
+
+const (
+	// The tournament itself, as GetTournamentById
+	WarmTournament WarmResource = "tournament"
+	// Its teams, as GetTeamsByTournamentId
+	WarmTeams WarmResource = "teams"
+	// The squad of each of its teams, as GetSquad
+	WarmSquads WarmResource = "squads"
+	// Its standings, as GetStandingsByTournament
+	WarmStandings WarmResource = "standings"
+	// The events of the next two weeks, as GetTournamentOverview fetches them
+	WarmUpcomingEvents WarmResource = "upcoming-events"
+)
+
+// AllWarmResources are the resources fetched when WarmerOptions.Resources is empty
+var AllWarmResources = []WarmResource{WarmTournament, WarmTeams, WarmSquads, WarmStandings, WarmUpcomingEvents}
+
+// WarmerOptions configures a Warmer
+type WarmerOptions struct {
+	// Requests made at once, DefaultWarmConcurrency when zero
+	Concurrency int
+	// Resources to fetch, AllWarmResources when empty
+	Resources []WarmResource
+	// How long each resource stays cached, CacheDuration for the ones missing
+	TTLs map[WarmResource]time.Duration
+}
+
+// WarmFailure is a request of a Warmer that failed
+type WarmFailure struct {
+	Resource WarmResource
+	// Tournament or team the request was for, zero for the upcoming events
+	ID  int
+	Err error
+}
+
+// WarmSummary reports what a run of a Warmer did
+type WarmSummary struct {
+	// Requests that succeeded, and so were cached
+	Succeeded int
+	Failed    []WarmFailure
+	Duration  time.Duration
+}
+
+// Err joins the errors of the failed requests, nil when all of them succeeded
+func (s WarmSummary) Err() error {
+	errs := make([]error, len(s.Failed))
+	for i, failure := range s.Failed {
+		errs[i] = fmt.Errorf("error warming %s %d: %w", failure.Resource, failure.ID, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// Warmer fills the cache with the data of a list of tournaments ahead of the traffic,
+// e.g. from a nightly job. Every request bypasses the cache lookup and stores a fresh
+// response, with the TTL of its resource. Requests count towards the budgets like any other
+type Warmer struct {
+	client *VSportsClient_s
+	opts   WarmerOptions
+}
+
+// NewWarmer creates a Warmer fetching through the client, and so caching in its cache
+func NewWarmer(c *VSportsClient_s, opts WarmerOptions) *Warmer {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultWarmConcurrency
+	}
+	if len(opts.Resources) == 0 {
+		opts.Resources = AllWarmResources
+	}
+	return &Warmer{client: c, opts: opts}
+}
+
+// Warm fetches the resources of the tournaments. The squads are fetched once the teams
+// are known, once per team even when it plays in several of the tournaments
+// It carries on after failures, which are reported in the summary
+func (w *Warmer) Warm(ctx context.Context, tournamentIDs ...int) WarmSummary {
+	c := w.client
+	start := c.clock.Now()
+	var summary WarmSummary
+	var mu sync.Mutex
+	done := func(resource WarmResource, id int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			summary.Failed = append(summary.Failed, WarmFailure{Resource: resource, ID: id, Err: err})
+		} else {
+			summary.Succeeded++
+		}
+	}
+
+	var teamIDs []int
+	var tasks []func() error
+	for _, tournamentID := range tournamentIDs {
+		if w.wants(WarmTournament) {
+			tasks = append(tasks, func() error {
+				_, err := c.GetTournamentById(ctx, tournamentID, w.options(WarmTournament)...)
+				done(WarmTournament, tournamentID, err)
+				return nil
+			})
+		}
+		if w.wants(WarmTeams) || w.wants(WarmSquads) {
+			tasks = append(tasks, func() error {
+				teams, err := c.GetTeamsByTournamentId(ctx, tournamentID, w.options(WarmTeams)...)
+				done(WarmTeams, tournamentID, err)
+				mu.Lock()
+				defer mu.Unlock()
+				for _, team := range teams {
+					teamIDs = append(teamIDs, team.ID)
+				}
+				return nil
+			})
+		}
+		if w.wants(WarmStandings) {
+			tasks = append(tasks, func() error {
+				_, err := c.GetStandingsByTournament(ctx, tournamentID, w.options(WarmStandings)...)
+				done(WarmStandings, tournamentID, err)
+				return nil
+			})
+		}
+	}
+	// The same request for all the tournaments, see GetTournamentOverview
+	if w.wants(WarmUpcomingEvents) && len(tournamentIDs) > 0 {
+		tasks = append(tasks, func() error {
+			today := DateOf(c.clock.Now().UTC())
+			_, err := c.GetEventsByDate(ctx, today, today.AddDays(upcomingEventsDays), w.options(WarmUpcomingEvents)...)
+			done(WarmUpcomingEvents, 0, err)
+			return nil
+		})
+	}
+	runConcurrently(w.opts.Concurrency, tasks...)
+
+	if w.wants(WarmSquads) {
+		slices.Sort(teamIDs)
+		tasks = nil
+		for _, teamID := range slices.Compact(teamIDs) {
+			tasks = append(tasks, func() error {
+				_, err := c.GetSquad(ctx, teamID, w.options(WarmSquads)...)
+				done(WarmSquads, teamID, err)
+				return nil
+			})
+		}
+		runConcurrently(w.opts.Concurrency, tasks...)
+	}
+
+	summary.Duration = c.clock.Now().Sub(start)
+	c.logger.Info(fmt.Sprintf("Warmed the cache for %d tournaments in %s: %d requests succeeded, %d failed", len(tournamentIDs), summary.Duration.Round(time.Millisecond), summary.Succeeded, len(summary.Failed)))
+	return summary
+}
+
+func (w *Warmer) wants(resource WarmResource) bool {
+	return slices.Contains(w.opts.Resources, resource)
+}
+
+// Options of the requests fetching a resource
+func (w *Warmer) options(resource WarmResource) []RequestOption {
+	return []RequestOption{WithForceRefresh(), WithTTL(w.opts.TTLs[resource])}
+}
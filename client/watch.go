@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// Defaults of WatchIntervalSeconds and WatchIdleIntervalSeconds
+const (
+	DefaultWatchInterval     = 10 * time.Second
+	DefaultWatchIdleInterval = time.Minute
+)
+
+// WatchOptions configures WatchEvent
+type WatchOptions struct {
+	// Time between polls while the event is live, WatchIntervalSeconds when zero
+	Interval time.Duration
+	// Time between polls before the event starts, WatchIdleIntervalSeconds when zero
+	IdleInterval time.Duration
+	// Keep watching once the event is over, e.g. for late corrections
+	// By default the channel is closed after the update where the event finished
+	KeepAfterEnd bool
+}
+
+// EventUpdate is delivered by WatchEvent when the event changed, or a poll failed
+type EventUpdate struct {
+	// The event as it is now, nil when Err is set
+	Event *Event
+	// The event as it was delivered last, nil on the first update
+	Previous *Event
+	// Lineups of the event, nil until announced
+	Lineup *Lineup
+	// What changed since Previous, see DiffEvents and DiffLineups. Nil on the first
+	// update, and empty when only what they don't cover changed, e.g. the minute
+	Changes []EventChange
+	// Error of a poll. Watching goes on, the next poll may succeed
+	Err error
+}
+
+// WatchEvent polls an event and delivers it on the returned channel every time its
+// response changes, so the caller only deals with actual updates. The first update is
+// the event as it is, the next ones tell what changed in their Changes. Polling is faster while the event is live, and stops once it's
+// over, unless opts.KeepAfterEnd is set, or when ctx is cancelled. The channel is
+// closed then. Polls bypass the cache but count towards the budgets like any request
+// They're conditional on the last response when the API sent an ETag or Last-Modified
+// with it, so an unchanged event only costs a 304 Not Modified
+//
+// The API has no push feed, so updates come at most every opts.Interval
+func (c *VSportsClient_s) WatchEvent(ctx context.Context, eventID int, opts WatchOptions) <-chan EventUpdate {
+	updates := make(chan EventUpdate)
+	go func() {
+		defer close(updates)
+		var previous *Event
+		var lineup *Lineup
+		var lastSum [sha256.Size]byte
+		var last cacheEntry
+		for {
+			event, current, sum, err := c.pollEvent(ctx, eventID, &last)
+			var update *EventUpdate
+			switch {
+			case err != nil && ctx.Err() != nil:
+				return
+			case err != nil:
+				c.logger.Warn(fmt.Sprintf("Error polling event %d: %v", eventID, err))
+				update = &EventUpdate{Previous: previous, Lineup: lineup, Err: err}
+			case previous == nil:
+				update = &EventUpdate{Event: event, Lineup: current}
+				previous, lineup, lastSum = event, current, sum
+			case sum != lastSum:
+				changes := append(DiffEvents(*previous, *event), DiffLineups(*event, lineup, current)...)
+				if changes == nil {
+					changes = []EventChange{}
+				}
+				update = &EventUpdate{Event: event, Previous: previous, Lineup: current, Changes: changes}
+				previous, lineup, lastSum = event, current, sum
+			}
+			if update != nil {
+				select {
+				case updates <- *update:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// Intervals left to the config follow its reloads
+			live, idle := c.settings.watchIntervals()
+			if opts.Interval > 0 {
+				live = opts.Interval
+			}
+			if opts.IdleInterval > 0 {
+				idle = opts.IdleInterval
+			}
+			interval := idle
+			if previous != nil {
+				switch previous.Status {
+				case EventStatusLive:
+					interval = live
+				case EventStatusFinished, EventStatusCancelled:
+					if !opts.KeepAfterEnd {
+						return
+					}
+				}
+			}
+			if sleepContext(ctx, c.clock, interval) != nil {
+				return
+			}
+		}
+	}()
+	return updates
+}
+
+// Fetches the detailed event and its lineups, along with a checksum of the response
+// telling if it changed. The request is conditional on last, the previous response,
+// which is replaced once the new one is decoded
+func (c *VSportsClient_s) pollEvent(ctx context.Context, eventID int, last *cacheEntry) (*Event, *Lineup, [sha256.Size]byte, error) {
+	endpoint := fmt.Sprintf("events/%d/detailed", eventID)
+	var v validators
+	body, err := c.request(ctx, endpoint, nil, WithNoCache(), withPrevious(*last, &v))
+	if err != nil {
+		return nil, nil, [sha256.Size]byte{}, err
+	}
+	events := make([]Event, 1)
+	if err := c.decodeResponse(endpoint, body, &events[0]); err != nil {
+		return nil, nil, [sha256.Size]byte{}, err
+	}
+	var teams detailedEventTeams
+	// Only a part of the event, strict decoding doesn't apply
+	if err := decode(body, &teams); err != nil {
+		return nil, nil, [sha256.Size]byte{}, err
+	}
+	*last = cacheEntry{body: body, validators: v}
+	c.enrich(ctx, events)
+	return &events[0], teams.lineup(), sha256.Sum256(body), nil
+}
@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+func TestWatchEventDeliversChanges(t *testing.T) {
+	var mu sync.Mutex
+	status, etag := client.EventStatusLive, `"v1"`
+	var notModified atomic.Int32
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id": 7, "status": %q}`, status)
+	})
+	clock := client.NewManualClock(time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC))
+	c := newTestClient(t, client.ClientConfig{Clock: clock}, api.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := c.WatchEvent(ctx, 7, client.WatchOptions{Interval: time.Second})
+
+	// Moves the clock along until the watcher polls and has something to deliver
+	next := func() (client.EventUpdate, bool) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case update, ok := <-updates:
+				return update, ok
+			case <-timeout:
+				t.Fatal("no update")
+			case <-time.After(time.Millisecond):
+				clock.Advance(time.Second)
+			}
+		}
+	}
+
+	first, _ := next()
+	if first.Err != nil || first.Event == nil || first.Event.Status != client.EventStatusLive || first.Changes != nil {
+		t.Fatalf("got first update %+v", first)
+	}
+
+	// Unchanged, the event only costs 304s and isn't delivered again
+	for notModified.Load() < 2 {
+		clock.Advance(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+	mu.Lock()
+	status, etag = client.EventStatusFinished, `"v2"`
+	mu.Unlock()
+
+	update, _ := next()
+	if update.Err != nil || update.Previous == nil || update.Previous.Status != client.EventStatusLive {
+		t.Fatalf("got update %+v", update)
+	}
+	if len(update.Changes) != 1 || update.Changes[0].Kind != client.ChangeStatus || update.Changes[0].Status != client.EventStatusFinished {
+		t.Errorf("got changes %+v, want the status change", update.Changes)
+	}
+
+	// Watching stops once the event is over
+	if update, ok := next(); ok {
+		t.Errorf("got update %+v after the event finished", update)
+	}
+}
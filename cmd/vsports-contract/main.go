@@ -0,0 +1,82 @@
+// Command vsports-contract checks the live VSports API against the recorded response schemas
+//
+// It's opt-in, as it spends real calls: it only runs with a key in VSPORTS_API_KEY, or a
+// config file given with -config. It exits with status 1 when any endpoint changed.
+//
+//	VSPORTS_API_KEY=... go run ./cmd/vsports-contract
+//	VSPORTS_API_KEY=... go run ./cmd/vsports-contract -update contract/schemas
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/sapo/vsports-go/client"
+	"github.com/sapo/vsports-go/contract"
+)
+
+func main() {
+	configPath := flag.String("config", "", "client config file, instead of VSPORTS_API_KEY")
+	update := flag.String("update", "", "record the current schemas in this directory instead of checking them")
+	asJSON := flag.Bool("json", false, "print the reports as JSON")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	var config client.ClientConfig
+	if *configPath != "" {
+		var err error
+		if config, err = client.LoadConfig(*configPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	} else {
+		config.APIKey = os.Getenv("VSPORTS_API_KEY")
+		if config.APIKey == "" {
+			fmt.Fprintln(os.Stderr, "contract checks are opt-in: set VSPORTS_API_KEY or use -config")
+			os.Exit(2)
+		}
+	}
+	// Samples are always fetched live, Redis is only used if it's there
+	config.Degraded.AllowWithoutCache = true
+
+	c, err := client.VSportsClient(config, logger)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	samples := contract.Discover(ctx, c)
+	if *update != "" {
+		if err := contract.Record(ctx, c, samples, *update); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	reports := contract.Check(ctx, c, samples, contract.Schemas)
+	failed := false
+	for _, report := range reports {
+		failed = failed || !report.OK()
+		if !*asJSON {
+			fmt.Println(report)
+		}
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+// Command vsports is a terminal client of the VSports API
+//
+// The API key is read from VSPORTS_API_KEY, or from a config file given with -config.
+//
+//	vsports watch -tournament 123    live table of the scores of today's matches
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+const usage = `usage: vsports <command> [flags]
+
+commands:
+  watch    live table of the scores of today's matches of a tournament
+
+Run "vsports <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "watch":
+		err = watch(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Builds a client from the config file, or from VSPORTS_API_KEY when there's none
+// Logs go to stderr, warnings and up only, so they don't garble the output
+func newClient(configPath string) (*client.VSportsClient_s, error) {
+	var config client.ClientConfig
+	if configPath != "" {
+		var err error
+		if config, err = client.LoadConfig(configPath); err != nil {
+			return nil, err
+		}
+	} else {
+		config.APIKey = os.Getenv("VSPORTS_API_KEY")
+		if config.APIKey == "" {
+			return nil, errors.New("set VSPORTS_API_KEY or use -config")
+		}
+	}
+	// A terminal client can do without Redis
+	config.Degraded.AllowWithoutCache = true
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	return client.VSportsClient(config, logger)
+}
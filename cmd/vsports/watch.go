@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// ANSI sequences used to redraw the table in place
+const (
+	clearScreen = "\033[H\033[2J"
+	hideCursor  = "\033[?25l"
+	showCursor  = "\033[?25h"
+)
+
+// Polls today's events of a tournament and redraws their scores until interrupted
+// Scores that changed since the previous refresh are marked with a "*"
+func watch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	tournamentID := flags.Int("tournament", 0, "ID of the tournament to watch (required)")
+	interval := flags.Duration("interval", 15*time.Second, "time between refreshes")
+	configPath := flags.String("config", "", "client config file, instead of VSPORTS_API_KEY")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *tournamentID <= 0 {
+		return errors.New("watch: -tournament is required")
+	}
+	if *interval < time.Second {
+		return errors.New("watch: -interval must be at least 1s")
+	}
+
+	c, err := newClient(*configPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Outside a terminal, every refresh is appended instead
+	terminal := isTerminal(os.Stdout)
+	if terminal {
+		fmt.Print(hideCursor)
+		defer fmt.Print(showCursor)
+	}
+
+	board := &scoreboard{tournamentID: *tournamentID, interval: *interval, previous: map[int]string{}}
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		board.refresh(ctx, c)
+		if terminal {
+			fmt.Print(clearScreen)
+		}
+		board.render(os.Stdout)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// The state of the watched tournament between refreshes
+type scoreboard struct {
+	tournamentID int
+	interval     time.Duration
+
+	name      string
+	events    []client.Event
+	updatedAt time.Time
+	// Error of the last refresh, the previous events are kept meanwhile
+	err error
+	// Scores of the previous refresh, by event ID
+	previous map[int]string
+	changed  map[int]bool
+}
+
+func (b *scoreboard) refresh(ctx context.Context, c *client.VSportsClient_s) {
+	today := client.DateOf(time.Now().UTC())
+	// Always live, the point is to watch the scores change
+	events, err := c.GetEventsDetailedByDate(ctx, today, today, client.WithNoCache())
+	b.err = err
+	if err != nil {
+		return
+	}
+
+	b.events = b.events[:0]
+	for _, event := range events {
+		if event.Tournament.ID == b.tournamentID {
+			b.events = append(b.events, event)
+		}
+	}
+	sort.Slice(b.events, func(i, j int) bool {
+		if !b.events[i].DateTime.Equal(b.events[j].DateTime) {
+			return b.events[i].DateTime.Before(b.events[j].DateTime)
+		}
+		return b.events[i].ID < b.events[j].ID
+	})
+
+	b.changed = map[int]bool{}
+	for _, event := range b.events {
+		if b.name == "" {
+			b.name = event.Tournament.Name
+		}
+		current := score(event)
+		if previous, ok := b.previous[event.ID]; ok && previous != current {
+			b.changed[event.ID] = true
+		}
+		b.previous[event.ID] = current
+	}
+	b.updatedAt = time.Now()
+}
+
+func (b *scoreboard) render(w io.Writer) {
+	name := b.name
+	if name == "" {
+		name = fmt.Sprintf("Tournament %d", b.tournamentID)
+	}
+	fmt.Fprintf(w, "%s, %s\n", name, time.Now().UTC().Format("2006-01-02"))
+	if !b.updatedAt.IsZero() {
+		fmt.Fprintf(w, "Updated at %s, every %s. Ctrl-C to quit\n", b.updatedAt.Format(time.TimeOnly), b.interval)
+	}
+	fmt.Fprintln(w)
+
+	if len(b.events) == 0 && b.err == nil {
+		fmt.Fprintln(w, "No matches today")
+	} else if len(b.events) > 0 {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "KICK-OFF\tSTATUS\tMINUTE\tHOME\tSCORE\tAWAY\t")
+		for _, event := range b.events {
+			mark := " "
+			if b.changed[event.ID] {
+				mark = "*"
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s %s\t%s\t\n",
+				kickOff(event), event.Status, minute(event), event.TeamA.Name, score(event), mark, event.TeamB.Name)
+		}
+		table.Flush()
+	}
+
+	if b.err != nil {
+		fmt.Fprintf(w, "\nError refreshing: %v\n", b.err)
+	}
+}
+
+func score(event client.Event) string {
+	s := fmt.Sprintf("%d-%d", event.Total_A, event.Total_B)
+	if event.PS_A != 0 || event.PS_B != 0 {
+		s += fmt.Sprintf(" (%d-%d p)", event.PS_A, event.PS_B)
+	}
+	return s
+}
+
+func minute(event client.Event) string {
+	switch {
+	case event.Minute == 0:
+		return ""
+	case event.MinuteExtra > 0:
+		return fmt.Sprintf("%d+%d'", event.Minute, event.MinuteExtra)
+	default:
+		return fmt.Sprintf("%d'", event.Minute)
+	}
+}
+
+// Kick-off in local time, falling back to the UTC time of the event
+func kickOff(event client.Event) string {
+	if !event.DateTime.IsZero() {
+		return event.DateTime.Local().Format("15:04")
+	}
+	return strings.TrimSuffix(event.TimeUTC, ":00")
+}
@@ -0,0 +1,146 @@
+// Package contract detects changes of the VSports API responses
+//
+// It fetches a sample response per endpoint with a real key, infers the structure of
+// each one and compares it with the schema stored for the endpoint, reporting the
+// fields added, removed or whose type changed. It's meant to run on a schedule or by
+// hand, see cmd/vsports-contract, and warns about silent API changes before they
+// show up as empty fields in production
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema maps the path of every field of a response to its JSON type
+// Paths look like "stage[].standings[].team.id", and types are "string", "number",
+// "boolean", "object" or "array". Fields that were always null are typed "null"
+type Schema map[string]string
+
+// Infer returns the schema of a JSON document
+// Array elements are merged, so a field found in any element is part of the schema
+func Infer(data []byte) (Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	schema := Schema{}
+	infer("", doc, schema)
+	return schema, nil
+}
+
+func infer(path string, v any, schema Schema) {
+	if path != "" {
+		t := jsonType(v)
+		// A null says nothing about the type, keep the one seen elsewhere
+		if existing, ok := schema[path]; !ok || existing == "null" {
+			schema[path] = t
+		}
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			infer(child, value, schema)
+		}
+	case []any:
+		for _, value := range v {
+			infer(path+"[]", value, schema)
+		}
+	}
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+// Change is a difference between the stored schema and the current response
+type Change struct {
+	Path string `json:"path"`
+	// Empty when the field was added
+	Was string `json:"was,omitempty"`
+	// Empty when the field was removed
+	Now string `json:"now,omitempty"`
+}
+
+// Report lists the changes of an endpoint
+type Report struct {
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Added    []Change `json:"added,omitempty"`
+	Removed  []Change `json:"removed,omitempty"`
+	Changed  []Change `json:"changed,omitempty"`
+	// Set when the sample couldn't be fetched or the schema couldn't be read
+	Error string `json:"error,omitempty"`
+}
+
+// OK tells if the response still matches the stored schema
+func (r Report) OK() bool {
+	return r.Error == "" && len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+func (r Report) String() string {
+	if r.Error != "" {
+		return fmt.Sprintf("%s (%s): %s", r.Name, r.Endpoint, r.Error)
+	}
+	if r.OK() {
+		return fmt.Sprintf("%s (%s): unchanged", r.Name, r.Endpoint)
+	}
+	var lines []string
+	for _, c := range r.Added {
+		lines = append(lines, fmt.Sprintf("  + %s (%s)", c.Path, c.Now))
+	}
+	for _, c := range r.Removed {
+		lines = append(lines, fmt.Sprintf("  - %s (%s)", c.Path, c.Was))
+	}
+	for _, c := range r.Changed {
+		lines = append(lines, fmt.Sprintf("  ~ %s (%s -> %s)", c.Path, c.Was, c.Now))
+	}
+	return fmt.Sprintf("%s (%s):\n%s", r.Name, r.Endpoint, strings.Join(lines, "\n"))
+}
+
+// Diff compares the stored schema of an endpoint with the current one
+// Fields null on either side don't count as a type change, as a sample can't tell
+// an optional field from one whose type changed
+func Diff(stored, current Schema) (added, removed, changed []Change) {
+	for path, now := range current {
+		was, ok := stored[path]
+		switch {
+		case !ok:
+			added = append(added, Change{Path: path, Now: now})
+		case was != now && was != "null" && now != "null":
+			changed = append(changed, Change{Path: path, Was: was, Now: now})
+		}
+	}
+	for path, was := range stored {
+		if _, ok := current[path]; !ok {
+			removed = append(removed, Change{Path: path, Was: was})
+		}
+	}
+	for _, changes := range [][]Change{added, removed, changed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	}
+	return added, removed, changed
+}
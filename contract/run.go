@@ -0,0 +1,178 @@
+package contract
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// Schemas holds the recorded schemas shipped with the package, named after their endpoint
+var Schemas, _ = fs.Sub(schemas, "schemas")
+
+// Sample is an endpoint checked by Run, with the path of the response fetched for it
+type Sample struct {
+	// Name of the schema file, without the .json extension
+	Name     string
+	Endpoint string
+	Params   map[string]string
+	// Set when no sample could be found for the endpoint
+	Err error
+}
+
+// Discover picks one sample per endpoint, following the IDs found in the responses:
+// the first tournament, its first team, that team's first player and venue, and the
+// most recent event of the last week
+func Discover(ctx context.Context, c *client.VSportsClient_s) []Sample {
+	var samples []Sample
+	add := func(name, endpoint string, params map[string]string, err error) {
+		samples = append(samples, Sample{Name: name, Endpoint: endpoint, Params: params, Err: err})
+	}
+
+	add("tournaments", "tournaments", nil, nil)
+	tournaments, err := c.GetTournaments(ctx, client.WithNoCache())
+	if err == nil && len(tournaments) == 0 {
+		err = errors.New("no tournaments")
+	}
+	if err != nil {
+		err = fmt.Errorf("error finding a tournament: %w", err)
+		for _, name := range []string{"standings", "team", "squad", "person", "venue"} {
+			add(name, "", nil, err)
+		}
+	} else {
+		tournamentID := tournaments[0].ID
+		add("standings", fmt.Sprintf("standings/by/tournament/%d", tournamentID), nil, nil)
+
+		teamID := 0
+		teams, err := c.GetTeamsByTournamentId(ctx, tournamentID, client.WithNoCache())
+		if err == nil && len(teams) == 0 {
+			err = errors.New("no teams")
+		}
+		if err != nil {
+			err = fmt.Errorf("error finding a team: %w", err)
+		} else {
+			teamID = teams[0].ID
+		}
+		add("team", fmt.Sprintf("teams/%d", teamID), nil, err)
+		add("squad", fmt.Sprintf("squads/%d", teamID), nil, err)
+
+		personID, venueID := 0, 0
+		personErr, venueErr := err, err
+		if err == nil {
+			squad, err := c.GetSquad(ctx, teamID, client.WithNoCache())
+			if err == nil && len(squad.Squad) == 0 {
+				err = errors.New("empty squad")
+			}
+			if err != nil {
+				personErr = fmt.Errorf("error finding a player: %w", err)
+			} else {
+				personID = squad.Squad[0].ID
+			}
+
+			venues, err := c.GetVenuesByTeam(ctx, teamID, client.WithNoCache())
+			if err == nil && len(venues) == 0 {
+				err = errors.New("no venues")
+			}
+			if err != nil {
+				venueErr = fmt.Errorf("error finding a venue: %w", err)
+			} else {
+				venueID = venues[0].ID
+			}
+		}
+		add("person", fmt.Sprintf("person/%d", personID), nil, personErr)
+		add("venue", fmt.Sprintf("venues/%d", venueID), nil, venueErr)
+	}
+
+	now := time.Now()
+	today := client.DateOf(now)
+	events, err := c.GetEventsByDate(ctx, today.AddDays(-7), today, client.WithNoCache())
+	if err == nil && len(events) == 0 {
+		err = errors.New("no events in the last week")
+	}
+	if err != nil {
+		add("event_detailed", "", nil, fmt.Errorf("error finding an event: %w", err))
+	} else {
+		add("event_detailed", fmt.Sprintf("events/%d/detailed", events[len(events)-1].ID), nil, nil)
+	}
+	return samples
+}
+
+// Check fetches the samples, bypassing the cache, and compares them with the schemas
+// found in fsys, named after the samples
+func Check(ctx context.Context, c *client.VSportsClient_s, samples []Sample, fsys fs.FS) []Report {
+	reports := make([]Report, 0, len(samples))
+	for _, sample := range samples {
+		report := Report{Name: sample.Name, Endpoint: sample.Endpoint}
+		current, err := fetch(ctx, c, sample)
+		if err != nil {
+			report.Error = err.Error()
+			reports = append(reports, report)
+			continue
+		}
+		stored, err := Load(fsys, sample.Name)
+		if err != nil {
+			report.Error = err.Error()
+			reports = append(reports, report)
+			continue
+		}
+		report.Added, report.Removed, report.Changed = Diff(stored, current)
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// Record fetches the samples and writes their schemas to dir, replacing the stored ones
+// Use it to accept the changes reported by Check
+func Record(ctx context.Context, c *client.VSportsClient_s, samples []Sample, dir string) error {
+	var errs []error
+	for _, sample := range samples {
+		schema, err := fetch(ctx, c, sample)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sample.Name, err))
+			continue
+		}
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: error encoding schema: %w", sample.Name, err))
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, sample.Name+".json"), append(data, '\n'), 0o644); err != nil {
+			errs = append(errs, fmt.Errorf("%s: error writing schema: %w", sample.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Load reads the schema of an endpoint from fsys
+func Load(fsys fs.FS, name string) (Schema, error) {
+	data, err := fs.ReadFile(fsys, name+".json")
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema: %w", err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("error decoding schema: %w", err)
+	}
+	return schema, nil
+}
+
+func fetch(ctx context.Context, c *client.VSportsClient_s, sample Sample) (Schema, error) {
+	if sample.Err != nil {
+		return nil, sample.Err
+	}
+	body, err := c.GetRaw(ctx, sample.Endpoint, sample.Params, client.WithNoCache())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sample: %w", err)
+	}
+	return Infer(body)
+}
@@ -0,0 +1,107 @@
+package golden
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"runtime/debug"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// Fuzz decodes data with decode into every model of the cases and runs the helpers that
+// work on the decoded values, such as the timeline and statistics ones. decode is the
+// decoding path under test, json.Unmarshal when nil. Malformed data is expected to fail
+// decoding; Fuzz only returns an error when something panicked. Use it as a fuzz target,
+// seeded with Seeds:
+//
+//	func FuzzDecode(f *testing.F) {
+//		for _, seed := range golden.Seeds(golden.Corpus) {
+//			f.Add(seed)
+//		}
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			if err := golden.Fuzz(data, nil); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+func Fuzz(data []byte, decode func(data []byte, v any) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic decoding %q: %v\n%s", data, r, debug.Stack())
+		}
+	}()
+	if decode == nil {
+		decode = json.Unmarshal
+	}
+
+	for _, c := range Cases {
+		v := c.New()
+		if decode(data, v) != nil {
+			continue
+		}
+		switch v := v.(type) {
+		case *client.Event:
+			exerciseEvents([]client.Event{*v})
+		case *client.Squad:
+			exerciseLineup(v.Squad)
+		case *client.Standings:
+			for _, stage := range v.Stage {
+				for _, entry := range stage.Standings {
+					entry.Zone()
+				}
+			}
+		case *client.Venue:
+			v.AllImages()
+			client.FindVenuesNear([]client.Venue{*v}, 0, 0, 100)
+		}
+	}
+	var events []client.Event
+	if decode(data, &events) == nil {
+		exerciseEvents(events)
+	}
+	var team client.TeamDetailed
+	if decode(data, &team) == nil {
+		if formation, err := client.ParseFormation(team.Formation); err == nil {
+			formation.Assign(team.Lineup)
+		}
+		exerciseLineup(team.Lineup)
+	}
+	return nil
+}
+
+func exerciseEvents(events []client.Event) {
+	for _, event := range events {
+		event.Goals()
+		event.VARIncidents()
+		event.DecidedOnPenalties()
+		event.Referee()
+		event.Kickoff()
+		client.SportOf(event)
+		event.FootballStatistics()
+		event.BasketballStatistics()
+		event.HandballStatistics()
+	}
+	client.AggregateCards(events, client.DefaultSuspensionRules)
+}
+
+func exerciseLineup(lineup []client.SquadMember) {
+	for _, member := range lineup {
+		member.PositionType()
+		member.PitchPosition()
+	}
+}
+
+// Seeds returns the fixtures found in fsys along with truncated copies of them,
+// as a starting corpus for Fuzz
+func Seeds(fsys fs.FS) [][]byte {
+	var seeds [][]byte
+	for _, c := range Cases {
+		data, err := fs.ReadFile(fsys, c.Name+".json")
+		if err != nil {
+			continue
+		}
+		seeds = append(seeds, data, data[:len(data)/2], data[:max(len(data)-1, 0)])
+	}
+	return seeds
+}
@@ -0,0 +1,197 @@
+// Package golden checks that the client models decode API responses completely
+//
+// Each case decodes a recorded response into its model, encodes the model back to JSON
+// and reports every field of the response that was lost on the way, i.e. fields the
+// models don't know about or decode wrongly. The package ships with a corpus of
+// anonymized responses, one per endpoint, in testdata. Run it from a test:
+//
+//	for _, result := range golden.Check(golden.Corpus) {
+//		if !result.OK() {
+//			t.Error(result)
+//		}
+//	}
+package golden
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+//go:embed testdata/*.json
+var corpus embed.FS
+
+// Corpus holds the recorded responses shipped with the package, named after their case
+var Corpus, _ = fs.Sub(corpus, "testdata")
+
+// Case is a recorded response of an endpoint and the model it decodes into
+type Case struct {
+	// Name of the fixture file, without the .json extension
+	Name     string
+	Endpoint string
+	// Returns a pointer to a fresh value of the model
+	New func() any
+}
+
+// Cases lists the endpoints covered by the corpus
+var Cases = []Case{
+	{Name: "tournaments", Endpoint: "tournaments", New: func() any { return &[]client.Tournament{} }},
+	{Name: "team", Endpoint: "teams/:id", New: func() any { return &client.Team{} }},
+	{Name: "event_detailed", Endpoint: "events/:id/detailed", New: func() any { return &client.Event{} }},
+	{Name: "squad", Endpoint: "squads/:id", New: func() any { return &client.Squad{} }},
+	{Name: "standings", Endpoint: "standings/by/tournament/:id", New: func() any { return &client.Standings{} }},
+	{Name: "venue", Endpoint: "venues/:id", New: func() any { return &client.Venue{} }},
+	{Name: "person", Endpoint: "person/:id", New: func() any { return &client.Person{} }},
+}
+
+// Result is the outcome of a case
+type Result struct {
+	Case Case
+	// Paths of the fields present in the response but missing after decoding, e.g. "stage[].zones[].color"
+	Dropped []string
+	Err     error
+}
+
+// OK tells if the response decoded completely
+func (r Result) OK() bool {
+	return r.Err == nil && len(r.Dropped) == 0
+}
+
+func (r Result) String() string {
+	switch {
+	case r.Err != nil:
+		return fmt.Sprintf("%s (%s): %v", r.Case.Name, r.Case.Endpoint, r.Err)
+	case len(r.Dropped) > 0:
+		return fmt.Sprintf("%s (%s): fields lost when decoding: %s", r.Case.Name, r.Case.Endpoint, strings.Join(r.Dropped, ", "))
+	}
+	return fmt.Sprintf("%s (%s): ok", r.Case.Name, r.Case.Endpoint)
+}
+
+// Check runs every case against the fixtures found in fsys, named after the cases
+// Cases without a fixture are reported with an error
+func Check(fsys fs.FS) []Result {
+	results := make([]Result, 0, len(Cases))
+	for _, c := range Cases {
+		result := Result{Case: c}
+		data, err := fs.ReadFile(fsys, c.Name+".json")
+		if err != nil {
+			result.Err = fmt.Errorf("error reading fixture: %w", err)
+		} else {
+			result.Dropped, result.Err = RoundTrip(data, c.New())
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// RoundTrip decodes data into v, a pointer to a model, encodes it back, and returns the
+// paths of the non-empty fields of data that didn't survive, sorted
+func RoundTrip(data []byte, v any) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var original any
+	if err := decoder.Decode(&original); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("error decoding: %w", err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding: %w", err)
+	}
+	decoder = json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var roundTripped any
+	if err := decoder.Decode(&roundTripped); err != nil {
+		return nil, fmt.Errorf("error decoding the encoded model: %w", err)
+	}
+
+	dropped := map[string]bool{}
+	compare("", original, roundTripped, dropped)
+	paths := make([]string, 0, len(dropped))
+	for path := range dropped {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Records the paths of want that are missing or different in got
+// Array elements share the path of their array, suffixed with []
+func compare(path string, want, got any, dropped map[string]bool) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, _ := got.(map[string]any)
+		for key, value := range w {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			if gv, ok := g[key]; ok {
+				compare(child, value, gv, dropped)
+			} else if !isEmpty(value) {
+				dropped[child] = true
+			}
+		}
+	case []any:
+		g, _ := got.([]any)
+		for i, value := range w {
+			if i < len(g) {
+				compare(path+"[]", value, g[i], dropped)
+			} else {
+				dropped[path+"[]"] = true
+			}
+		}
+	default:
+		if !isEmpty(want) && fmt.Sprint(want) != fmt.Sprint(got) && !sameTime(want, got) {
+			dropped[path] = true
+		}
+	}
+}
+
+// Tells if both values are timestamps of the same instant, which the models may
+// encode with another offset notation, e.g. "Z" for "+00:00"
+func sameTime(want, got any) bool {
+	w, _ := want.(string)
+	g, _ := got.(string)
+	wt, err := time.Parse(time.RFC3339, w)
+	if err != nil {
+		return false
+	}
+	gt, err := time.Parse(time.RFC3339, g)
+	return err == nil && wt.Equal(gt)
+}
+
+// Tells if a JSON value is the zero value omitempty leaves out
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		for _, value := range v {
+			if !isEmpty(value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
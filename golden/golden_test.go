@@ -0,0 +1,13 @@
+package golden
+
+import "testing"
+
+func TestCorpus(t *testing.T) {
+	for _, result := range Check(Corpus) {
+		t.Run(result.Case.Name, func(t *testing.T) {
+			if !result.OK() {
+				t.Error(result)
+			}
+		})
+	}
+}
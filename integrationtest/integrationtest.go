@@ -0,0 +1,190 @@
+// Package integrationtest runs a real client against Redis and a mock VSports API, for
+// end-to-end tests of the caching, retries, failover and circuit breaking of the client
+//
+// Redis runs in a container started with testcontainers, so Docker is needed, and tests
+// are skipped when it isn't available. Set VSPORTS_TEST_REDIS_ADDR to use a Redis server
+// of your own instead, e.g. a CI service. Each environment gets a cache namespace of its
+// own, so tests sharing a server don't see each other's entries
+//
+//	func TestStandingsAreCached(t *testing.T) {
+//		env := integrationtest.New(t, integrationtest.Options{})
+//		env.Client.GetStandingsByTournament(context.Background(), 1)
+//		env.Client.GetStandingsByTournament(context.Background(), 1)
+//		if calls := env.API.Calls("standings/by/tournament/:id"); calls != 1 {
+//			t.Errorf("got %d upstream calls, want 1", calls)
+//		}
+//	}
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/sapo/vsports-go/client"
+	"github.com/sapo/vsports-go/golden"
+)
+
+// DefaultRedisImage is the image of the Redis container
+const DefaultRedisImage = "redis:7-alpine"
+
+// RedisAddrEnv names the environment variable pointing to a Redis server to use instead of a container
+const RedisAddrEnv = "VSPORTS_TEST_REDIS_ADDR"
+
+// TB is the part of testing.TB the package needs
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Fatalf(format string, args ...any)
+	Skipf(format string, args ...any)
+	Name() string
+}
+
+var _ TB = testing.TB(nil)
+
+// Options configures an environment
+type Options struct {
+	// Base of the client config. The API key, base URLs, Redis address and cache
+	// namespace are set by New
+	Config client.ClientConfig
+	// Fixtures served by the mock API, golden.Corpus when nil
+	Fixtures fs.FS
+	// DefaultRedisImage when empty
+	RedisImage string
+	// Fail the test instead of skipping it when Docker isn't available
+	RequireDocker bool
+	// Logger of the client, none when nil
+	Logger *slog.Logger
+}
+
+// Env is a client wired to Redis and to a mock API
+type Env struct {
+	Client    *client.VSportsClient_s
+	API       *MockAPI
+	RedisAddr string
+	// Connected to the same server as the client, to inspect or clear the cache
+	Redis *redis.Client
+	// Cache namespace of the client
+	Namespace string
+}
+
+var namespaces atomic.Int64
+
+// New starts the mock API and Redis, and builds a client using both
+// Everything is stopped when the test ends
+func New(t TB, opts Options) *Env {
+	t.Helper()
+	if opts.Fixtures == nil {
+		opts.Fixtures = golden.Corpus
+	}
+	api := NewMockAPI(t, opts.Fixtures)
+	addr := StartRedis(t, opts.RedisImage, opts.RequireDocker)
+
+	namespace := fmt.Sprintf("integrationtest-%d-%d", os.Getpid(), namespaces.Add(1))
+	config := opts.Config
+	config.APIKey = APIKey
+	config.APIKeys = nil
+	config.BaseURLs = []string{api.URL}
+	config.RedisConfig.Addr = addr
+	config.CacheNamespace = namespace
+
+	c, err := client.VSportsClient(config, opts.Logger)
+	if err != nil {
+		t.Fatalf("integrationtest: creating the client: %v", err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { rdb.Close() })
+	return &Env{Client: c, API: api, RedisAddr: addr, Redis: rdb, Namespace: namespace}
+}
+
+// StartRedis starts a Redis container and returns its address, or the address in
+// RedisAddrEnv when set. The container is removed when the test ends
+// Without Docker, the test is skipped, or failed if requireDocker is set
+func StartRedis(t TB, image string, requireDocker bool) string {
+	t.Helper()
+	if addr := os.Getenv(RedisAddrEnv); addr != "" {
+		return addr
+	}
+	if image == "" {
+		image = DefaultRedisImage
+	}
+
+	ctx := context.Background()
+	container, err := runRedis(ctx, image)
+	if container != nil {
+		t.Cleanup(func() { testcontainers.TerminateContainer(container) })
+	}
+	if err != nil {
+		if requireDocker {
+			t.Fatalf("integrationtest: starting Redis: %v", err)
+		}
+		t.Skipf("integrationtest: Redis container unavailable, is Docker running? %v", err)
+	}
+	addr, err := container.Endpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("integrationtest: getting the Redis address: %v", err)
+	}
+	return addr
+}
+
+// testcontainers panics when it finds no Docker host at all
+// A plain container rather than the Redis module, which brings a Redis client of its own
+func runRedis(ctx context.Context, image string) (container testcontainers.Container, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        image,
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+}
+
+// CacheKeys returns the keys the client stored in Redis
+func (e *Env) CacheKeys(t TB) []string {
+	t.Helper()
+	keys, err := e.Redis.Keys(context.Background(), "vsports://"+e.Namespace+"/*").Result()
+	if err != nil {
+		t.Fatalf("integrationtest: listing the cache keys: %v", err)
+	}
+	return keys
+}
+
+// FlushCache deletes the entries the client stored in Redis, leaving those of other environments
+func (e *Env) FlushCache(t TB) {
+	t.Helper()
+	keys := e.CacheKeys(t)
+	if len(keys) == 0 {
+		return
+	}
+	if err := e.Redis.Del(context.Background(), keys...).Err(); err != nil {
+		t.Fatalf("integrationtest: flushing the cache: %v", err)
+	}
+}
+
+// HasCached tells if a response of the endpoint is in the cache
+// The endpoint is matched as a substring of the keys, e.g. "standings/by/tournament/1"
+func (e *Env) HasCached(t TB, endpoint string) bool {
+	t.Helper()
+	for _, key := range e.CacheKeys(t) {
+		if strings.Contains(key, endpoint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package integrationtest
+
+import (
+	"io/fs"
+
+	"github.com/sapo/vsports-go/golden"
+	"github.com/sapo/vsports-go/vsportstest"
+)
+
+// APIKey is the key the mock API expects, requests with another one get a 401
+const APIKey = vsportstest.APIKey
+
+// Route returns the route of an endpoint, with the IDs replaced by ":id", e.g. "teams/:id"
+func Route(endpoint string) string {
+	return vsportstest.Route(endpoint)
+}
+
+// MockAPI is the mock VSports API of vsportstest, answering with the golden cases
+// Routes can be scripted to fail or slow down to test the retries, failover, circuit
+// breakers and caching of the client. Unknown routes get a 404
+type MockAPI = vsportstest.Server
+
+// NewMockAPI starts a mock API serving the golden cases of the fixtures, e.g. golden.Corpus
+// It's closed when the test ends
+func NewMockAPI(t TB, fixtures fs.FS) *MockAPI {
+	t.Helper()
+	m := vsportstest.NewServer(t, vsportstest.Fixtures{})
+	for _, c := range golden.Cases {
+		body, err := fs.ReadFile(fixtures, c.Name+".json")
+		if err != nil {
+			continue
+		}
+		m.Handle(c.Endpoint, body)
+	}
+	return m
+}
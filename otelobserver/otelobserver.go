@@ -0,0 +1,88 @@
+// Package otelobserver traces the requests of a vsports client with OpenTelemetry
+//
+// Every request gets a client span, named after its route, with the cache decision, the
+// upstream status and the retries recorded on it. Calls made with a context already
+// carrying a span are traced as its children
+//
+//	c.AddObserver(otelobserver.New(otel.GetTracerProvider()))
+package otelobserver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// InstrumentationName is the name of the tracer
+const InstrumentationName = "github.com/sapo/vsports-go/otelobserver"
+
+// Observer is a client.Observer creating a span per request
+type Observer struct {
+	client.NopObserver
+	tracer trace.Tracer
+}
+
+// New creates an observer tracing with a tracer of the provider
+func New(provider trace.TracerProvider) *Observer {
+	return &Observer{tracer: provider.Tracer(InstrumentationName)}
+}
+
+func (o *Observer) OnRequestStart(ctx context.Context, info client.RequestInfo) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "vsports "+info.Route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("vsports.route", info.Route),
+			attribute.String("vsports.endpoint", info.Endpoint),
+			attribute.String("vsports.group", info.Group),
+		))
+	return ctx
+}
+
+func (o *Observer) OnRequestEnd(ctx context.Context, info client.RequestInfo, result client.RequestResult) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("vsports.cache", result.Cache),
+		attribute.Bool("vsports.upstream", result.Upstream),
+		attribute.Int("vsports.response_bytes", result.Bytes),
+	)
+	if result.Status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", result.Status))
+	}
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	span.End()
+}
+
+func (o *Observer) OnCacheHit(ctx context.Context, info client.RequestInfo) {
+	trace.SpanFromContext(ctx).AddEvent("cache hit")
+}
+
+func (o *Observer) OnCacheMiss(ctx context.Context, info client.RequestInfo) {
+	trace.SpanFromContext(ctx).AddEvent("cache miss")
+}
+
+func (o *Observer) OnRetry(ctx context.Context, retry client.RetryInfo) {
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.String("vsports.retry.reason", retry.Reason),
+		attribute.Int("vsports.retry.attempt", retry.Attempt),
+		attribute.String("vsports.retry.error", retry.Err.Error()),
+	))
+}
+
+func (o *Observer) OnDeprecation(ctx context.Context, notice client.DeprecationNotice) {
+	attrs := []attribute.KeyValue{attribute.String("vsports.route", notice.Route)}
+	if !notice.Sunset.IsZero() {
+		attrs = append(attrs, attribute.String("vsports.deprecation.sunset", notice.Sunset.UTC().Format(time.RFC3339)))
+	}
+	if notice.Link != "" {
+		attrs = append(attrs, attribute.String("vsports.deprecation.link", notice.Link))
+	}
+	trace.SpanFromContext(ctx).AddEvent("deprecated endpoint", trace.WithAttributes(attrs...))
+}
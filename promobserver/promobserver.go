@@ -0,0 +1,129 @@
+// Package promobserver exports the events of a vsports client as Prometheus metrics
+//
+//	observer, err := promobserver.New(prometheus.DefaultRegisterer, "")
+//	if err != nil {
+//		return err
+//	}
+//	c.AddObserver(observer)
+package promobserver
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// DefaultNamespace prefixes the metric names when New isn't given one
+const DefaultNamespace = "vsports"
+
+// Observer is a client.Observer updating Prometheus metrics
+// Every metric is labelled by route, e.g. "teams/:id", so the cardinality stays low
+type Observer struct {
+	client.NopObserver
+
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	cacheHits   *prometheus.CounterVec
+	cacheMisses *prometheus.CounterVec
+	retries     *prometheus.CounterVec
+	breaker     *prometheus.GaugeVec
+	deprecated  *prometheus.GaugeVec
+}
+
+// New creates the metrics and registers them with reg
+func New(reg prometheus.Registerer, namespace string) (*Observer, error) {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	o := &Observer{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Requests handled by the client, by route, cache decision and outcome.",
+		}, []string{"route", "cache", "status", "outcome"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests handled by the client, by route and whether the API was called.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "upstream"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Requests served from the cache, by route.",
+		}, []string{"route"}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_misses_total",
+			Help:      "Requests not found in the cache, by route.",
+		}, []string{"route"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Retries made by the client, by route and reason.",
+		}, []string{"route", "reason"}),
+		breaker: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of each endpoint group: 0 closed, 1 half-open, 2 open.",
+		}, []string{"group"}),
+		deprecated: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "deprecated_endpoint_sunset_timestamp_seconds",
+			Help:      "Endpoints the API reported as deprecated, by route, with their sunset time or 0 when unknown.",
+		}, []string{"route"}),
+	}
+	for _, collector := range []prometheus.Collector{o.requests, o.duration, o.cacheHits, o.cacheMisses, o.retries, o.breaker, o.deprecated} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+func (o *Observer) OnRequestEnd(ctx context.Context, info client.RequestInfo, result client.RequestResult) {
+	outcome := "success"
+	if result.Err != nil {
+		outcome = "error"
+	}
+	status := ""
+	if result.Status != 0 {
+		status = strconv.Itoa(result.Status)
+	}
+	o.requests.WithLabelValues(info.Route, result.Cache, status, outcome).Inc()
+	o.duration.WithLabelValues(info.Route, strconv.FormatBool(result.Upstream)).Observe(result.Duration.Seconds())
+}
+
+func (o *Observer) OnCacheHit(ctx context.Context, info client.RequestInfo) {
+	o.cacheHits.WithLabelValues(info.Route).Inc()
+}
+
+func (o *Observer) OnCacheMiss(ctx context.Context, info client.RequestInfo) {
+	o.cacheMisses.WithLabelValues(info.Route).Inc()
+}
+
+func (o *Observer) OnRetry(ctx context.Context, retry client.RetryInfo) {
+	o.retries.WithLabelValues(retry.Request.Route, retry.Reason).Inc()
+}
+
+func (o *Observer) OnBreakerStateChange(group, from, to string) {
+	value := 0.0
+	switch to {
+	case client.BreakerHalfOpen:
+		value = 1
+	case client.BreakerOpen:
+		value = 2
+	}
+	o.breaker.WithLabelValues(group).Set(value)
+}
+
+func (o *Observer) OnDeprecation(ctx context.Context, notice client.DeprecationNotice) {
+	sunset := 0.0
+	if !notice.Sunset.IsZero() {
+		sunset = float64(notice.Sunset.Unix())
+	}
+	o.deprecated.WithLabelValues(notice.Route).Set(sunset)
+}
@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// BoltStore is a Store in a BoltDB file, with a bucket per kind
+// The file can only be opened by one process at a time
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// How a record is stored, under its ID
+type boltRecord struct {
+	Data       json.RawMessage `json:"data"`
+	SyncedAt   time.Time       `json:"syncedAt"`
+	ModifiedAt time.Time       `json:"modifiedAt"`
+}
+
+// OpenBoltStore opens the store of a file, creating it when missing
+// It fails after a second when another process has the file open
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening snapshot store %s: %w", path, err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// IDs are big endian so the buckets are sorted by them
+func boltKey(id int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+func (s *BoltStore) Get(ctx context.Context, kind Kind, id int) (record Record, found bool, err error) {
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(kind))
+		if bucket == nil {
+			return nil
+		}
+		value := bucket.Get(boltKey(id))
+		if value == nil {
+			return nil
+		}
+		record, err = decodeBoltRecord(kind, id, value)
+		found = err == nil
+		return err
+	})
+	return record, found, err
+}
+
+func (s *BoltStore) Put(ctx context.Context, records ...Record) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for _, record := range records {
+			bucket, err := tx.CreateBucketIfNotExists([]byte(record.Kind))
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(boltRecord{Data: record.Data, SyncedAt: record.SyncedAt, ModifiedAt: record.ModifiedAt})
+			if err != nil {
+				return fmt.Errorf("error encoding %s %d: %w", record.Kind, record.ID, err)
+			}
+			if err := bucket.Put(boltKey(record.ID), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) List(ctx context.Context, kind Kind) ([]Record, error) {
+	var records []Record
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(kind))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			record, err := decodeBoltRecord(kind, int(binary.BigEndian.Uint64(key)), value)
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// Decodes a stored record, copying its data as bbolt's is only valid in the transaction
+func decodeBoltRecord(kind Kind, id int, value []byte) (Record, error) {
+	var stored boltRecord
+	if err := json.Unmarshal(value, &stored); err != nil {
+		return Record{}, fmt.Errorf("error decoding %s %d: %w", kind, id, err)
+	}
+	return Record{Kind: kind, ID: id, Data: stored.Data, SyncedAt: stored.SyncedAt, ModifiedAt: stored.ModifiedAt}, nil
+}
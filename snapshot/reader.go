@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// Reader serves the resources of a snapshot, with the methods of the client they were
+// synced with. Resources missing from the store are ErrNotSynced
+type Reader struct {
+	store Store
+}
+
+func NewReader(store Store) *Reader {
+	return &Reader{store: store}
+}
+
+// Decodes the record of a resource into v
+func (r *Reader) get(ctx context.Context, kind Kind, id int, v any) error {
+	record, found, err := r.store.Get(ctx, kind, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s %d", ErrNotSynced, kind, id)
+	}
+	if err := json.Unmarshal(record.Data, v); err != nil {
+		return fmt.Errorf("error decoding %s %d: %w", kind, id, err)
+	}
+	return nil
+}
+
+// LastModified returns when a resource last changed, and when it was last synced
+func (r *Reader) LastModified(ctx context.Context, kind Kind, id int) (modifiedAt, syncedAt time.Time, err error) {
+	record, found, err := r.store.Get(ctx, kind, id)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s %d", ErrNotSynced, kind, id)
+	}
+	return record.ModifiedAt, record.SyncedAt, nil
+}
+
+// GetTournaments returns the tournaments synced
+func (r *Reader) GetTournaments(ctx context.Context) ([]client.Tournament, error) {
+	records, err := r.store.List(ctx, KindTournament)
+	if err != nil {
+		return nil, err
+	}
+	tournaments := make([]client.Tournament, len(records))
+	for i, record := range records {
+		if err := json.Unmarshal(record.Data, &tournaments[i]); err != nil {
+			return nil, fmt.Errorf("error decoding %s %d: %w", record.Kind, record.ID, err)
+		}
+	}
+	return tournaments, nil
+}
+
+func (r *Reader) GetTournamentById(ctx context.Context, tournamentID int) (*client.Tournament, error) {
+	var tournament client.Tournament
+	if err := r.get(ctx, KindTournament, tournamentID, &tournament); err != nil {
+		return nil, err
+	}
+	return &tournament, nil
+}
+
+func (r *Reader) GetTeamsByTournamentId(ctx context.Context, tournamentID int) ([]client.Team, error) {
+	var teams []client.Team
+	if err := r.get(ctx, KindTeams, tournamentID, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *Reader) GetSquad(ctx context.Context, teamID int) (*client.Squad, error) {
+	var squad client.Squad
+	if err := r.get(ctx, KindSquad, teamID, &squad); err != nil {
+		return nil, err
+	}
+	return &squad, nil
+}
+
+func (r *Reader) GetStandingsByTournament(ctx context.Context, tournamentID int) (*client.Standings, error) {
+	var standings client.Standings
+	if err := r.get(ctx, KindStandings, tournamentID, &standings); err != nil {
+		return nil, err
+	}
+	return &standings, nil
+}
+
+func (r *Reader) GetEventById(ctx context.Context, eventID int) (*client.Event, error) {
+	var event client.Event
+	if err := r.get(ctx, KindEvent, eventID, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetEventsByDate returns the events synced starting between two days in UTC, included,
+// by kickoff. Like the client, it fails with client.ErrInvalidDateRange for a bad range
+func (r *Reader) GetEventsByDate(ctx context.Context, startDate, endDate client.Date) ([]client.Event, error) {
+	if startDate.IsZero() || endDate.IsZero() || startDate.After(endDate) {
+		return nil, fmt.Errorf("%w: %s to %s", client.ErrInvalidDateRange, startDate, endDate)
+	}
+	records, err := r.store.List(ctx, KindEvent)
+	if err != nil {
+		return nil, err
+	}
+	var events []client.Event
+	for _, record := range records {
+		var event client.Event
+		if err := json.Unmarshal(record.Data, &event); err != nil {
+			return nil, fmt.Errorf("error decoding %s %d: %w", record.Kind, record.ID, err)
+		}
+		kickoff, ok := event.Kickoff()
+		if !ok {
+			continue
+		}
+		if day := client.DateOf(kickoff); !day.Before(startDate) && !day.After(endDate) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].DateTime.Before(events[j].DateTime) })
+	return events, nil
+}
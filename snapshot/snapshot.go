@@ -0,0 +1,106 @@
+// Package snapshot keeps a local copy of the data of a list of tournaments, so batch
+// jobs can read it without depending on the API being up, nor spending its quota
+//
+//	store, err := snapshot.OpenBoltStore("vsports.db")
+//	if err != nil {
+//		return err
+//	}
+//	defer store.Close()
+//	syncer := snapshot.NewSyncer(c, store, snapshot.SyncerOptions{Tournaments: []int{1, 2}})
+//	go syncer.Run(ctx)
+//
+//	standings, err := snapshot.NewReader(store).GetStandingsByTournament(ctx, 1)
+//
+// The Syncer pulls the tournaments, their teams, squads, standings and events into a
+// Store, and the Reader serves them back with the methods of the client
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotSynced is returned by the Reader for the resources missing from the store
+var ErrNotSynced = errors.New("not in the snapshot")
+
+// Kind is a kind of resource of a snapshot
+type Kind string
+
+const (
+	// A tournament, by its ID
+	KindTournament Kind = "tournament"
+	// The teams of a tournament, by the tournament ID
+	KindTeams Kind = "teams"
+	// The squad of a team, by the team ID
+	KindSquad Kind = "squad"
+	// The standings of a tournament, by the tournament ID
+	KindStandings Kind = "standings"
+	// An event, by its ID
+	KindEvent Kind = "event"
+)
+
+// Record is a resource of a snapshot
+type Record struct {
+	Kind Kind
+	ID   int
+	// The resource as JSON, as the client models encode it
+	Data []byte
+	// When it was last synced, and when its data last changed
+	SyncedAt   time.Time
+	ModifiedAt time.Time
+}
+
+// Store holds the records of a snapshot
+// It's safe for concurrent use, the Syncer writes while the Reader reads
+type Store interface {
+	// Get returns the record of a resource
+	// A missing record is not an error: found is false and err is nil
+	Get(ctx context.Context, kind Kind, id int) (record Record, found bool, err error)
+	// Put adds records, replacing the ones with the same kind and ID
+	Put(ctx context.Context, records ...Record) error
+	// List returns the records of a kind, by ID
+	List(ctx context.Context, kind Kind) ([]Record, error)
+}
+
+// MemoryStore is a Store in memory, e.g. for tests or short-lived jobs
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[Kind]map[int]Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[Kind]map[int]Record{}}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, kind Kind, id int) (Record, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, found := s.records[kind][id]
+	return record, found, nil
+}
+
+func (s *MemoryStore) Put(ctx context.Context, records ...Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range records {
+		if s.records[record.Kind] == nil {
+			s.records[record.Kind] = map[int]Record{}
+		}
+		s.records[record.Kind][record.ID] = record
+	}
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, kind Kind) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]Record, 0, len(s.records[kind]))
+	for _, record := range s.records[kind] {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	return records, nil
+}
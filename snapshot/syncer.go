@@ -0,0 +1,225 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+const (
+	// DefaultSyncInterval is the time between the syncs of Run when SyncerOptions.Interval isn't set
+	DefaultSyncInterval = time.Hour
+	// DefaultSyncConcurrency is how many requests a Syncer makes at once when SyncerOptions.Concurrency isn't set
+	DefaultSyncConcurrency = 4
+	// Days of events synced before and after today when SyncerOptions doesn't set them
+	DefaultEventsDaysBack  = 7
+	DefaultEventsDaysAhead = 14
+)
+
+// SyncerOptions configures a Syncer
+type SyncerOptions struct {
+	// Tournaments to sync, along with their teams, squads, standings and events
+	Tournaments []int
+	// Time between the syncs of Run, DefaultSyncInterval when zero
+	Interval time.Duration
+	// Requests made at once, DefaultSyncConcurrency when zero
+	Concurrency int
+	// Events synced, from EventsDaysBack days before today to EventsDaysAhead days
+	// after it. The events leaving that window are kept as last synced
+	EventsDaysBack  int
+	EventsDaysAhead int
+	// Called after each sync of Run, e.g. to log its summary
+	OnSync func(SyncSummary)
+	// Source of the sync times, client.SystemClock when nil
+	Clock client.Clock
+}
+
+// SyncFailure is a resource a sync failed to fetch or store
+type SyncFailure struct {
+	Kind Kind
+	// Tournament or team the resource is for
+	ID  int
+	Err error
+}
+
+// SyncSummary reports what a sync did
+type SyncSummary struct {
+	// Records written, and how many of them changed since the previous sync
+	Synced   int
+	Modified int
+	Failed   []SyncFailure
+	Duration time.Duration
+}
+
+// Err joins the errors of the failures, nil when there are none
+func (s SyncSummary) Err() error {
+	errs := make([]error, len(s.Failed))
+	for i, failure := range s.Failed {
+		errs[i] = fmt.Errorf("error syncing %s %d: %w", failure.Kind, failure.ID, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// Syncer pulls the data of a list of tournaments from the API into a Store
+// Every request skips the cache, so the snapshot is as fresh as the API. Resources that
+// fail keep their last synced record
+type Syncer struct {
+	client *client.VSportsClient_s
+	store  Store
+	opts   SyncerOptions
+}
+
+// NewSyncer creates a Syncer fetching through the client into the store
+func NewSyncer(c *client.VSportsClient_s, store Store, opts SyncerOptions) *Syncer {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultSyncInterval
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultSyncConcurrency
+	}
+	if opts.EventsDaysBack == 0 {
+		opts.EventsDaysBack = DefaultEventsDaysBack
+	}
+	if opts.EventsDaysAhead == 0 {
+		opts.EventsDaysAhead = DefaultEventsDaysAhead
+	}
+	if opts.Clock == nil {
+		opts.Clock = client.SystemClock{}
+	}
+	return &Syncer{client: c, store: store, opts: opts}
+}
+
+// Run syncs right away and then every Interval, until ctx is done, returning its error
+func (s *Syncer) Run(ctx context.Context) error {
+	ticker := s.opts.Clock.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		summary := s.Sync(ctx)
+		if s.opts.OnSync != nil {
+			s.opts.OnSync(summary)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+// Sync fetches the resources of the tournaments once and stores them. The squads are
+// fetched once the teams are known, once per team even when it plays in several of the
+// tournaments. It carries on after failures, which are reported in the summary
+func (s *Syncer) Sync(ctx context.Context) SyncSummary {
+	c := s.client
+	start := s.opts.Clock.Now()
+	var summary SyncSummary
+	var mu sync.Mutex
+	// Stores the resources fetched, or reports the error fetching them
+	done := func(kind Kind, id int, err error, records ...Record) {
+		var modified int
+		if err == nil {
+			modified, err = s.put(ctx, records)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			summary.Failed = append(summary.Failed, SyncFailure{Kind: kind, ID: id, Err: err})
+			return
+		}
+		summary.Synced += len(records)
+		summary.Modified += modified
+	}
+
+	today := client.DateOf(start.UTC())
+	var teamIDs []int
+	group := errgroup.Group{}
+	group.SetLimit(s.opts.Concurrency)
+	for _, tournamentID := range s.opts.Tournaments {
+		group.Go(func() error {
+			tournament, err := c.GetTournamentById(ctx, tournamentID, client.WithNoCache())
+			done(KindTournament, tournamentID, err, record(KindTournament, tournamentID, tournament))
+			return nil
+		})
+		group.Go(func() error {
+			teams, err := c.GetTeamsByTournamentId(ctx, tournamentID, client.WithNoCache())
+			done(KindTeams, tournamentID, err, record(KindTeams, tournamentID, teams))
+			mu.Lock()
+			defer mu.Unlock()
+			for _, team := range teams {
+				teamIDs = append(teamIDs, team.ID)
+			}
+			return nil
+		})
+		group.Go(func() error {
+			standings, err := c.GetStandingsByTournament(ctx, tournamentID, client.WithNoCache())
+			done(KindStandings, tournamentID, err, record(KindStandings, tournamentID, standings))
+			return nil
+		})
+		group.Go(func() error {
+			var records []Record
+			pager := c.Events(ctx, client.EventsQuery{
+				StartDate:    today.AddDays(-s.opts.EventsDaysBack),
+				EndDate:      today.AddDays(s.opts.EventsDaysAhead),
+				TournamentID: tournamentID,
+			}, client.WithNoCache())
+			for pager.Next() {
+				event := pager.Event()
+				records = append(records, record(KindEvent, event.ID, event))
+			}
+			done(KindEvent, tournamentID, pager.Err(), records...)
+			return nil
+		})
+	}
+	group.Wait()
+
+	slices.Sort(teamIDs)
+	for _, teamID := range slices.Compact(teamIDs) {
+		group.Go(func() error {
+			squad, err := c.GetSquad(ctx, teamID, client.WithNoCache())
+			done(KindSquad, teamID, err, record(KindSquad, teamID, squad))
+			return nil
+		})
+	}
+	group.Wait()
+
+	summary.Duration = s.opts.Clock.Now().Sub(start)
+	return summary
+}
+
+// A record of a resource, with its data only, see put
+func record(kind Kind, id int, v any) Record {
+	data, _ := json.Marshal(v)
+	return Record{Kind: kind, ID: id, Data: data}
+}
+
+// Stores records as synced now, keeping the modification time of the ones unchanged,
+// and returns how many changed
+func (s *Syncer) put(ctx context.Context, records []Record) (int, error) {
+	now := s.opts.Clock.Now()
+	modified := 0
+	for i, record := range records {
+		previous, found, err := s.store.Get(ctx, record.Kind, record.ID)
+		if err != nil {
+			return 0, err
+		}
+		records[i].SyncedAt, records[i].ModifiedAt = now, now
+		if found && bytes.Equal(previous.Data, record.Data) {
+			records[i].ModifiedAt = previous.ModifiedAt
+		} else {
+			modified++
+		}
+	}
+	if err := s.store.Put(ctx, records...); err != nil {
+		return 0, err
+	}
+	return modified, nil
+}
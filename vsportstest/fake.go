@@ -0,0 +1,220 @@
+package vsportstest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// FakeName is the name Fake reports as a client.Provider
+const FakeName = "vsportstest"
+
+// Fake implements client.VSportsAPI from fixtures held in memory, for unit tests of
+// code depending on the interface. IDs missing from the fixtures fail with an error
+// wrapping client.ErrNotFound, like the API's 404, and the request options are ignored
+type Fake struct {
+	mu       sync.Mutex
+	fixtures Fixtures
+	failures map[string]error
+	calls    map[string]int
+}
+
+var _ client.VSportsAPI = (*Fake)(nil)
+
+// NewFake returns a fake serving the fixtures
+func NewFake(fixtures Fixtures) *Fake {
+	return &Fake{fixtures: fixtures, failures: map[string]error{}, calls: map[string]int{}}
+}
+
+// SetFixtures replaces the data served
+func (f *Fake) SetFixtures(fixtures Fixtures) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fixtures = fixtures
+}
+
+// Fail makes every call of the method, e.g. "GetStandingsByTournament", fail with err,
+// until it's called again with a nil err
+func (f *Fake) Fail(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.failures, method)
+		return
+	}
+	f.failures[method] = err
+}
+
+// Calls returns how many times the method was called, an empty method counting them all
+func (f *Fake) Calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if method != "" {
+		return f.calls[method]
+	}
+	total := 0
+	for _, n := range f.calls {
+		total += n
+	}
+	return total
+}
+
+// Counts the call of the method, and returns the fixtures to answer it with, or the
+// failure set for it
+func (f *Fake) call(ctx context.Context, method string) (Fixtures, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+	if err := ctx.Err(); err != nil {
+		return Fixtures{}, err
+	}
+	return f.fixtures, f.failures[method]
+}
+
+func notFound(kind string, id int) error {
+	return fmt.Errorf("%w: %s %d", client.ErrNotFound, kind, id)
+}
+
+func validDateRange(start, end client.Date) error {
+	switch {
+	case start.IsZero() || end.IsZero():
+		return fmt.Errorf("%w: the start and the end are both required", client.ErrInvalidDateRange)
+	case start.After(end):
+		return fmt.Errorf("%w: %s is after %s", client.ErrInvalidDateRange, start, end)
+	}
+	return nil
+}
+
+func (f *Fake) Name() string {
+	return FakeName
+}
+
+func (f *Fake) GetTournaments(ctx context.Context, opts ...client.RequestOption) ([]client.Tournament, error) {
+	fixtures, err := f.call(ctx, "GetTournaments")
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(nonNil(fixtures.Tournaments)), nil
+}
+
+func (f *Fake) GetTournamentsByType(ctx context.Context, types []client.CompetitionType, opts ...client.RequestOption) ([]client.Tournament, error) {
+	fixtures, err := f.call(ctx, "GetTournamentsByType")
+	if err != nil {
+		return nil, err
+	}
+	return client.TournamentsOfType(slices.Clone(fixtures.Tournaments), types...), nil
+}
+
+func (f *Fake) GetTournamentById(ctx context.Context, tournamentID int, opts ...client.RequestOption) (*client.Tournament, error) {
+	fixtures, err := f.call(ctx, "GetTournamentById")
+	if err != nil {
+		return nil, err
+	}
+	tournament, ok := fixtures.tournament(tournamentID)
+	if !ok {
+		return nil, notFound("tournament", tournamentID)
+	}
+	return &tournament, nil
+}
+
+func (f *Fake) GetTeamById(ctx context.Context, teamID int, opts ...client.RequestOption) (*client.Team, error) {
+	fixtures, err := f.call(ctx, "GetTeamById")
+	if err != nil {
+		return nil, err
+	}
+	team, ok := fixtures.team(teamID)
+	if !ok {
+		return nil, notFound("team", teamID)
+	}
+	return &team, nil
+}
+
+func (f *Fake) GetTeamsByTournamentId(ctx context.Context, tournamentID int, opts ...client.RequestOption) ([]client.Team, error) {
+	fixtures, err := f.call(ctx, "GetTeamsByTournamentId")
+	if err != nil {
+		return nil, err
+	}
+	return nonNil(fixtures.teamsOf(tournamentID)), nil
+}
+
+func (f *Fake) GetEventsByDate(ctx context.Context, startDate, endDate client.Date, opts ...client.RequestOption) ([]client.Event, error) {
+	return f.eventsByDate(ctx, "GetEventsByDate", startDate, endDate)
+}
+
+func (f *Fake) GetEventsDetailedByDate(ctx context.Context, startDate, endDate client.Date, opts ...client.RequestOption) ([]client.Event, error) {
+	return f.eventsByDate(ctx, "GetEventsDetailedByDate", startDate, endDate)
+}
+
+func (f *Fake) eventsByDate(ctx context.Context, method string, startDate, endDate client.Date) ([]client.Event, error) {
+	if err := validDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	fixtures, err := f.call(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	return fixtures.eventsBetween(startDate, endDate, nil), nil
+}
+
+func (f *Fake) GetEventById(ctx context.Context, eventID int, opts ...client.RequestOption) (*client.Event, error) {
+	return f.event(ctx, "GetEventById", eventID)
+}
+
+func (f *Fake) GetEventDetailed(ctx context.Context, eventID int, opts ...client.RequestOption) (*client.Event, error) {
+	return f.event(ctx, "GetEventDetailed", eventID)
+}
+
+func (f *Fake) event(ctx context.Context, method string, eventID int) (*client.Event, error) {
+	fixtures, err := f.call(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	event, ok := fixtures.event(eventID)
+	if !ok {
+		return nil, notFound("event", eventID)
+	}
+	return &event, nil
+}
+
+func (f *Fake) GetSquad(ctx context.Context, teamID int, opts ...client.RequestOption) (*client.Squad, error) {
+	return f.squad(ctx, "GetSquad", teamID)
+}
+
+func (f *Fake) GetSquadDetailed(ctx context.Context, teamID int, opts ...client.RequestOption) (*client.Squad, error) {
+	return f.squad(ctx, "GetSquadDetailed", teamID)
+}
+
+func (f *Fake) squad(ctx context.Context, method string, teamID int) (*client.Squad, error) {
+	fixtures, err := f.call(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	squad, ok := fixtures.squad(teamID)
+	if !ok {
+		return nil, notFound("squad of team", teamID)
+	}
+	return &squad, nil
+}
+
+func (f *Fake) GetStandingsByTournament(ctx context.Context, tournamentID int, opts ...client.RequestOption) (*client.Standings, error) {
+	return f.standings(ctx, "GetStandingsByTournament", tournamentID)
+}
+
+func (f *Fake) GetStandingsByTournamentLive(ctx context.Context, tournamentID int, opts ...client.RequestOption) (*client.Standings, error) {
+	return f.standings(ctx, "GetStandingsByTournamentLive", tournamentID)
+}
+
+func (f *Fake) standings(ctx context.Context, method string, tournamentID int) (*client.Standings, error) {
+	fixtures, err := f.call(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	standings, ok := fixtures.standings(tournamentID)
+	if !ok {
+		return nil, notFound("standings of tournament", tournamentID)
+	}
+	return &standings, nil
+}
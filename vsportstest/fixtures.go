@@ -0,0 +1,159 @@
+// Package vsportstest helps testing code using the VSports client without an API key or
+// Redis. Server is an httptest server answering like the VSports API from fixtures, for
+// tests going through a real client, and Fake serves the same fixtures straight from
+// memory to code depending on client.VSportsAPI:
+//
+//	func TestStandingsPage(t *testing.T) {
+//		fixtures, err := vsportstest.LoadFixtures(os.DirFS("testdata"))
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		server := vsportstest.NewServer(t, fixtures)
+//		page := NewStandingsPage(server.NewClient(t))
+//		// or, without HTTP at all
+//		page = NewStandingsPage(vsportstest.NewFake(fixtures))
+//	}
+package vsportstest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// Fixtures is the data served by Server and Fake
+// Events are served by ID, by day and by tournament. The teams of a tournament are those
+// playing its events or listed in its standings, and squads are served by the ID of their team
+// The entries of standings built in code have no Zone, only those decoded from JSON do
+type Fixtures struct {
+	Tournaments []client.Tournament `json:"tournaments"`
+	Teams       []client.Team       `json:"teams"`
+	Events      []client.Event      `json:"events"`
+	Squads      []client.Squad      `json:"squads"`
+	Standings   []client.Standings  `json:"standings"`
+}
+
+// Files read by LoadFixtures, each holding a JSON array of the models as the API sends them
+const (
+	TournamentsFile = "tournaments.json"
+	TeamsFile       = "teams.json"
+	EventsFile      = "events.json"
+	SquadsFile      = "squads.json"
+	StandingsFile   = "standings.json"
+)
+
+// LoadFixtures reads the fixture files found at the root of fsys, e.g. os.DirFS("testdata")
+// Missing files leave their part of the fixtures empty
+func LoadFixtures(fsys fs.FS) (Fixtures, error) {
+	var fixtures Fixtures
+	files := []struct {
+		name string
+		v    any
+	}{
+		{TournamentsFile, &fixtures.Tournaments},
+		{TeamsFile, &fixtures.Teams},
+		{EventsFile, &fixtures.Events},
+		{SquadsFile, &fixtures.Squads},
+		{StandingsFile, &fixtures.Standings},
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file.name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fixtures, fmt.Errorf("error reading fixtures %s: %w", file.name, err)
+		}
+		if err := json.Unmarshal(data, file.v); err != nil {
+			return fixtures, fmt.Errorf("error decoding fixtures %s: %w", file.name, err)
+		}
+	}
+	return fixtures, nil
+}
+
+func (f Fixtures) tournament(id int) (client.Tournament, bool) {
+	return find(f.Tournaments, func(t client.Tournament) bool { return t.ID == id })
+}
+
+func (f Fixtures) team(id int) (client.Team, bool) {
+	return find(f.Teams, func(t client.Team) bool { return t.ID == id })
+}
+
+func (f Fixtures) event(id int) (client.Event, bool) {
+	return find(f.Events, func(e client.Event) bool { return e.ID == id })
+}
+
+func (f Fixtures) squad(teamID int) (client.Squad, bool) {
+	return find(f.Squads, func(s client.Squad) bool { return s.Team.ID == teamID })
+}
+
+func (f Fixtures) standings(tournamentID int) (client.Standings, bool) {
+	return find(f.Standings, func(s client.Standings) bool { return s.TournamentID == tournamentID })
+}
+
+// Returns the teams playing the events of the tournament or listed in its standings, in
+// the order they're first found, as given in Teams when they're there
+func (f Fixtures) teamsOf(tournamentID int) []client.Team {
+	var teams []client.Team
+	seen := map[int]bool{}
+	add := func(team client.Team) {
+		if team.ID == 0 || seen[team.ID] {
+			return
+		}
+		seen[team.ID] = true
+		if fixture, ok := f.team(team.ID); ok {
+			team = fixture
+		}
+		teams = append(teams, team)
+	}
+	for _, event := range f.Events {
+		if event.Tournament.ID == tournamentID {
+			add(event.TeamA)
+			add(event.TeamB)
+		}
+	}
+	if standings, ok := f.standings(tournamentID); ok {
+		for _, stage := range standings.Stage {
+			for _, entry := range stage.Standings {
+				add(entry.Team)
+			}
+		}
+	}
+	return teams
+}
+
+// Returns the events from start to end, both included, keeping those matching
+func (f Fixtures) eventsBetween(start, end client.Date, keep func(client.Event) bool) []client.Event {
+	events := []client.Event{}
+	for _, event := range f.Events {
+		day := dayOf(event)
+		if day.Before(start) || day.After(end) || (keep != nil && !keep(event)) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// The day the API puts an event on
+func dayOf(event client.Event) client.Date {
+	if day, err := client.ParseDate(event.DateUTC); err == nil {
+		return day
+	}
+	if !event.DateTime.IsZero() {
+		return client.DateOf(event.DateTime.UTC())
+	}
+	return client.Date{}
+}
+
+func find[T any](items []T, match func(T) bool) (T, bool) {
+	if i := slices.IndexFunc(items, match); i >= 0 {
+		return items[i], true
+	}
+	var zero T
+	return zero, false
+}
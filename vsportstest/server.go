@@ -0,0 +1,305 @@
+package vsportstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// APIKey is the key Server expects, requests with another one get a 401
+const APIKey = "vsportstest-key"
+
+// TB is the part of testing.TB the package needs
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Fatalf(format string, args ...any)
+}
+
+var numericSegment = regexp.MustCompile(`(^|/)\d+(/|$)`)
+
+// Route returns the route of an endpoint, with the IDs replaced by ":id", e.g. "teams/:id"
+func Route(endpoint string) string {
+	endpoint = strings.Trim(endpoint, "/")
+	// Run twice since consecutive IDs share the separating slash
+	for i := 0; i < 2; i++ {
+		endpoint = numericSegment.ReplaceAllString(endpoint, "$1:id$2")
+	}
+	return endpoint
+}
+
+// Server is an httptest server answering like the VSports API from fixtures
+// It serves the tournaments, teams, events, squads and standings endpoints, filtering
+// and paginating the events as the API does. Bodies set with Handle answer any other
+// endpoint, or override the fixtures. Other endpoints, and IDs missing from the
+// fixtures, get a 404
+// Requests can be scripted to fail or slow down, to test the retries, failover, circuit
+// breakers and caching of the client. Fail and Calls take an endpoint, e.g.
+// "teams/201", or a route matching all the IDs, e.g. "teams/:id"
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	fixtures Fixtures
+	bodies   map[string][]byte
+	failures map[string][]int
+	latency  time.Duration
+	calls    map[string]int
+	total    int
+}
+
+// NewServer starts a server answering from the fixtures, closed when the test ends
+func NewServer(t TB, fixtures Fixtures) *Server {
+	t.Helper()
+	s := &Server{fixtures: fixtures, bodies: map[string][]byte{}, failures: map[string][]int{}, calls: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewClient builds a client of the server, caching in memory unless the options say
+// otherwise, e.g. client.WithCache(client.NopCache{}) to see every request through
+// A config given with client.WithConfig replaces the memory cache, so set its cache too
+func (s *Server) NewClient(t TB, opts ...client.Option) *client.VSportsClient_s {
+	t.Helper()
+	opts = append([]client.Option{client.WithCache(client.NewMemoryCache(0))}, opts...)
+	opts = append(opts, client.WithBaseURL(s.URL))
+	c, err := client.New(APIKey, opts...)
+	if err != nil {
+		t.Fatalf("vsportstest: creating the client: %v", err)
+	}
+	return c
+}
+
+// SetFixtures replaces the data served, e.g. to test how changes are picked up
+func (s *Server) SetFixtures(fixtures Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures = fixtures
+}
+
+// Handle makes the endpoint or route, e.g. "events/:id/detailed", answer with the body
+func (s *Server) Handle(endpoint string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies[strings.Trim(endpoint, "/")] = body
+}
+
+// Fail makes the next requests to the endpoint or route answer with the statuses, one
+// per request. An empty endpoint fails the next requests to any endpoint
+func (s *Server) Fail(endpoint string, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint = strings.Trim(endpoint, "/")
+	s.failures[endpoint] = append(s.failures[endpoint], statuses...)
+}
+
+// SetLatency delays every response, e.g. to test timeouts and deadlines
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// Calls returns how many requests reached the endpoint or route, an empty endpoint
+// counting them all
+func (s *Server) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint == "" {
+		return s.total
+	}
+	return s.calls[strings.Trim(endpoint, "/")]
+}
+
+// Reset forgets the calls, scripted failures and latency, keeping the fixtures and bodies
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = map[string]int{}
+	s.total = 0
+	s.failures = map[string][]int{}
+	s.latency = 0
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.Trim(r.URL.Path, "/")
+	route := Route(endpoint)
+	keys := []string{endpoint}
+	if route != endpoint {
+		keys = append(keys, route)
+	}
+
+	s.mu.Lock()
+	s.total++
+	for _, key := range keys {
+		s.calls[key]++
+	}
+	status := 0
+	// Failures scripted for the endpoint come before those for its route, and those for
+	// any endpoint last
+	for _, key := range append(keys, "") {
+		if queue := s.failures[key]; len(queue) > 0 {
+			status, s.failures[key] = queue[0], queue[1:]
+			break
+		}
+	}
+	var body []byte
+	for _, key := range keys {
+		if b, ok := s.bodies[key]; ok {
+			body = b
+			break
+		}
+	}
+	fixtures, latency := s.fixtures, s.latency
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	switch {
+	case r.Header.Get("Authorization") != "Bearer "+APIKey:
+		writeError(w, http.StatusUnauthorized, "invalid api key")
+		return
+	case status != 0:
+		writeError(w, status, fmt.Sprintf("scripted failure %d", status))
+		return
+	case body != nil:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+		return
+	}
+
+	data, err := fixtures.answer(strings.Split(endpoint, "/"), r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if data == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if events, ok := data.([]client.Event); ok {
+		data = paginate(w, r, events)
+	}
+	body, err = json.Marshal(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// Returns what the endpoint answers, nil when it's not found
+func (f Fixtures) answer(path []string, r *http.Request) (any, error) {
+	id := 0
+	if len(path) > 1 {
+		id, _ = strconv.Atoi(path[1])
+	}
+	found := func(v any, ok bool) (any, error) {
+		if !ok {
+			return nil, nil
+		}
+		return v, nil
+	}
+
+	switch {
+	// tournaments, tournaments/:id
+	case path[0] == "tournaments" && len(path) == 1:
+		return nonNil(f.Tournaments), nil
+	case path[0] == "tournaments" && len(path) == 2:
+		return found(f.tournament(id))
+	// teams/:id, teams/by/tournament/:id
+	case path[0] == "teams" && len(path) == 2:
+		return found(f.team(id))
+	case path[0] == "teams" && len(path) == 4 && path[1] == "by" && path[2] == "tournament":
+		id, _ = strconv.Atoi(path[3])
+		return nonNil(f.teamsOf(id)), nil
+	// events, events/detailed, events/:id, events/:id/detailed
+	case path[0] == "events" && (len(path) == 1 || (len(path) == 2 && path[1] == "detailed")):
+		return f.queryEvents(r)
+	case path[0] == "events" && (len(path) == 2 || (len(path) == 3 && path[2] == "detailed")):
+		return found(f.event(id))
+	// squads/:id, squads/:id/detailed
+	case path[0] == "squads" && (len(path) == 2 || (len(path) == 3 && path[2] == "detailed")):
+		return found(f.squad(id))
+	// standings/by/tournament/:id, standings/by/tournament/:id/live
+	case path[0] == "standings" && (len(path) == 4 || (len(path) == 5 && path[4] == "live")) && path[1] == "by" && path[2] == "tournament":
+		id, _ = strconv.Atoi(path[3])
+		return found(f.standings(id))
+	}
+	return nil, nil
+}
+
+// Answers the events endpoint, filtered by the query as the API does
+func (f Fixtures) queryEvents(r *http.Request) ([]client.Event, error) {
+	query := r.URL.Query()
+	start, err := client.ParseDate(query.Get("start_date"))
+	if err != nil {
+		return nil, err
+	}
+	end, err := client.ParseDate(query.Get("end_date"))
+	if err != nil {
+		return nil, err
+	}
+	tournamentID, _ := strconv.Atoi(query.Get("tournament_id"))
+	teamID, _ := strconv.Atoi(query.Get("team_id"))
+	var statuses []string
+	if list := query.Get("status"); list != "" {
+		statuses = strings.Split(list, ",")
+	}
+	season := query.Get("season")
+
+	return f.eventsBetween(start, end, func(e client.Event) bool {
+		return (tournamentID == 0 || e.Tournament.ID == tournamentID) &&
+			(teamID == 0 || e.TeamA.ID == teamID || e.TeamB.ID == teamID) &&
+			(len(statuses) == 0 || slices.Contains(statuses, e.Status)) &&
+			(season == "" || e.Tournament.Season == season)
+	}), nil
+}
+
+// Returns the page of the events asked for, with their total in the header, or all of
+// them when no page was
+func paginate(w http.ResponseWriter, r *http.Request, events []client.Event) []client.Event {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit <= 0 {
+		return events
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if page, _ := strconv.Atoi(query.Get("page")); page > 0 {
+		offset = (page - 1) * limit
+	}
+	w.Header().Set(client.TotalCountHeader, strconv.Itoa(len(events)))
+	offset = min(offset, len(events))
+	return events[offset:min(offset+limit, len(events))]
+}
+
+// Lists are never answered with null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
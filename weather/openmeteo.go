@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OpenMeteoURL is the forecast endpoint of Open-Meteo, which needs no API key
+const OpenMeteoURL = "https://api.open-meteo.com/v1/forecast"
+
+// OpenMeteo is a Provider backed by the Open-Meteo forecast API, which covers the next 16 days
+type OpenMeteo struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewOpenMeteo creates an Open-Meteo provider using the given http client
+// If httpClient is nil, http.DefaultClient is used
+func NewOpenMeteo(httpClient *http.Client) *OpenMeteo {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &OpenMeteo{BaseURL: OpenMeteoURL, HTTPClient: httpClient}
+}
+
+func (o *OpenMeteo) Name() string {
+	return "open-meteo"
+}
+
+type openMeteoResponse struct {
+	Hourly struct {
+		Time                     []string  `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		PrecipitationProbability []int     `json:"precipitation_probability"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		WeatherCode              []int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+func (o *OpenMeteo) Forecast(ctx context.Context, lat, lon float64, at time.Time) (*Forecast, error) {
+	hour := at.UTC().Truncate(time.Hour)
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(lat, 'f', 4, 64))
+	params.Set("longitude", strconv.FormatFloat(lon, 'f', 4, 64))
+	params.Set("hourly", "temperature_2m,precipitation_probability,wind_speed_10m,weather_code")
+	params.Set("timezone", "UTC")
+	params.Set("start_hour", hour.Format("2006-01-02T15:04"))
+	params.Set("end_hour", hour.Format("2006-01-02T15:04"))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", o.BaseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating forecast request: %w", err)
+	}
+	resp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting forecast: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Times out of the forecast range are refused with a 400
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, ErrNoForecast
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting forecast: %s", resp.Status)
+	}
+
+	var body openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("error decoding forecast: %w", err)
+	}
+	hourly := body.Hourly
+	if len(hourly.Time) == 0 || len(hourly.Temperature) == 0 || len(hourly.PrecipitationProbability) == 0 ||
+		len(hourly.WindSpeed) == 0 || len(hourly.WeatherCode) == 0 {
+		return nil, ErrNoForecast
+	}
+
+	return &Forecast{
+		Time:                     hour,
+		TemperatureCelsius:       hourly.Temperature[0],
+		PrecipitationProbability: hourly.PrecipitationProbability[0],
+		WindSpeedKmh:             hourly.WindSpeed[0],
+		Code:                     hourly.WeatherCode[0],
+		Provider:                 o.Name(),
+	}, nil
+}
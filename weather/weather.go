@@ -0,0 +1,78 @@
+// Package weather is a client.Enricher adding the weather forecast at the venue to events
+//
+//	c.AddEnricher(weather.NewEnricher(weather.NewOpenMeteo(nil)))
+//
+// The forecast is then found with weather.FromEvent(event)
+package weather
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sapo/vsports-go/client"
+)
+
+// ExtensionName is the key of the forecast in client.Event.Extensions
+const ExtensionName = "weather"
+
+// Forecast is the weather expected at a place and time
+type Forecast struct {
+	Time                     time.Time `json:"time"`
+	TemperatureCelsius       float64   `json:"temperatureCelsius"`
+	PrecipitationProbability int       `json:"precipitationProbability"` // percentage
+	WindSpeedKmh             float64   `json:"windSpeedKmh"`
+	// WMO weather interpretation code, e.g. 0 for clear sky
+	Code     int    `json:"code"`
+	Provider string `json:"provider"`
+}
+
+// Provider is a source of weather forecasts
+type Provider interface {
+	Name() string
+	// Forecast returns the forecast for the given point at the given time
+	// ErrNoForecast is returned when the time is too far away for the provider
+	Forecast(ctx context.Context, lat, lon float64, at time.Time) (*Forecast, error)
+}
+
+// ErrNoForecast is returned by providers that have no forecast for the requested time
+var ErrNoForecast = errors.New("no forecast available")
+
+// Enricher attaches the forecast at the venue and kickoff time to events
+// Events without venue coordinates or kickoff time, already played, or too far
+// in the future for the provider are left as they are
+type Enricher struct {
+	provider Provider
+	now      func() time.Time
+}
+
+// NewEnricher creates an enricher getting its forecasts from the given provider
+func NewEnricher(provider Provider) *Enricher {
+	return &Enricher{provider: provider, now: time.Now}
+}
+
+func (e *Enricher) Name() string {
+	return ExtensionName
+}
+
+func (e *Enricher) Enrich(ctx context.Context, event *client.Event) error {
+	kickoff, ok := event.Kickoff()
+	if !ok || kickoff.Before(e.now()) || !event.Venue.HasCoordinates() {
+		return nil
+	}
+	forecast, err := e.provider.Forecast(ctx, *event.Venue.Latitude, *event.Venue.Longitude, kickoff)
+	if errors.Is(err, ErrNoForecast) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	event.SetExtension(ExtensionName, forecast)
+	return nil
+}
+
+// FromEvent returns the forecast attached to an event by the Enricher
+func FromEvent(event client.Event) (*Forecast, bool) {
+	forecast, ok := event.Extensions[ExtensionName].(*Forecast)
+	return forecast, ok
+}